@@ -0,0 +1,208 @@
+package ward
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+//ErrUnverifiedAccessorial is returned when an accessorial code that hasn't been confirmed with Ward would be sent
+//See SetAllowUnverifiedAccessorials.
+var ErrUnverifiedAccessorial = errors.New("ward - accessorial code has not been verified with Ward")
+
+//AccessorialCode is a code for a special characteristic of a shipment, such as needing a liftgate
+type AccessorialCode string
+
+//accessorial codes
+//These are NOT verified against Ward's api documentation, they are the codes commonly used by other LTL carriers
+//and may not be what Ward expects.  Confirm them with Ward before relying on them.  They aren't added to
+//requests automatically unless SetAllowUnverifiedAccessorials is on, see verifiedAccessorials.
+const (
+	AccessorialLiftgatePickup        AccessorialCode = "LIFTO"
+	AccessorialLiftgateDelivery      AccessorialCode = "LIFTD"
+	AccessorialInsidePickup          AccessorialCode = "INSP"
+	AccessorialInsideDelivery        AccessorialCode = "INSD"
+	AccessorialResidentialPickup     AccessorialCode = "RESP"
+	AccessorialResidentialDelivery   AccessorialCode = "RESD"
+	AccessorialLimitedAccessPickup   AccessorialCode = "LTDP"
+	AccessorialLimitedAccessDelivery AccessorialCode = "LTDD"
+	AccessorialProtectFromFreeze     AccessorialCode = "PFZ"
+	AccessorialHazardous             AccessorialCode = "HAZ"
+	AccessorialAppointment           AccessorialCode = "APPT"
+	AccessorialNotify                AccessorialCode = "NOTIFY"
+
+	//AccessorialLiftgate is a liftgate at delivery, the usual meaning of "needs a liftgate"
+	AccessorialLiftgate = AccessorialLiftgateDelivery
+)
+
+//accessorialCatalog is every accessorial code this package knows of with a description
+//Being listed here doesn't mean Ward accepts the code, see verifiedAccessorials.
+var accessorialCatalog = map[AccessorialCode]string{
+	AccessorialLiftgatePickup:        "liftgate at pickup",
+	AccessorialLiftgateDelivery:      "liftgate at delivery",
+	AccessorialInsidePickup:          "inside pickup",
+	AccessorialInsideDelivery:        "inside delivery",
+	AccessorialResidentialPickup:     "residential pickup",
+	AccessorialResidentialDelivery:   "residential delivery",
+	AccessorialLimitedAccessPickup:   "limited access pickup",
+	AccessorialLimitedAccessDelivery: "limited access delivery",
+	AccessorialProtectFromFreeze:     "protect from freeze",
+	AccessorialHazardous:             "hazardous materials",
+	AccessorialAppointment:           "delivery appointment required",
+	AccessorialNotify:                "notify consignee before delivery",
+}
+
+//verifiedAccessorials are the codes confirmed against Ward's api documentation
+//Add a code here once it has been confirmed so it is sent without SetAllowUnverifiedAccessorials.
+var verifiedAccessorials = map[AccessorialCode]bool{}
+
+//SetAllowUnverifiedAccessorials chooses if accessorial codes that haven't been confirmed with Ward are added to
+//requests automatically
+//This affects AccessorialsFor and the accessorial added for a rate quote's DeliveryAppointment.  Only turn this on
+//once you have checked the codes with Ward, an unknown code may be ignored and the quote will be missing the
+//charge.  This is off by default.
+func SetAllowUnverifiedAccessorials(yes bool) {
+	defaultClient.SetAllowUnverifiedAccessorials(yes)
+	return
+}
+
+//SetAllowUnverifiedAccessorials chooses if unconfirmed accessorial codes are added to requests automatically, see
+//SetAllowUnverifiedAccessorials
+func (c *Client) SetAllowUnverifiedAccessorials(yes bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.allowUnverifiedAccessorials = yes
+	return
+}
+
+//accessorialAllowed checks if a code may be added to a request automatically
+func (c *Client) accessorialAllowed(code AccessorialCode) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.allowUnverifiedAccessorials || verifiedAccessorials[code]
+}
+
+//ShipmentAttributes describes what a shipment needs at pickup and delivery
+//Use AccessorialsFor to turn this into the accessorial codes Ward expects instead of looking up codes, after
+//opting in with SetAllowUnverifiedAccessorials.
+type ShipmentAttributes struct {
+	ResidentialPickup     bool
+	ResidentialDelivery   bool
+	LiftgatePickup        bool
+	LiftgateDelivery      bool
+	InsidePickup          bool
+	InsideDelivery        bool
+	LimitedAccessPickup   bool
+	LimitedAccessDelivery bool
+	ProtectFromFreeze     bool
+	Hazardous             bool
+	DeliveryAppointment   Appointment
+}
+
+//AccessorialsFor returns the accessorials for a shipment's attributes, ready to use in a rate quote request
+//NOTE: this is OFF until you opt in.  None of the accessorial codes have been confirmed with Ward yet, so any attribute
+//that needs a code returns ErrUnverifiedAccessorial, with no accessorials, until you check the codes with Ward
+//and call SetAllowUnverifiedAccessorials(true).  Only attributes that need no code, such as an empty
+//ShipmentAttributes, succeed without opting in.
+func AccessorialsFor(attrs ShipmentAttributes) (items []RateQuoteAccessorialItem, err error) {
+	return defaultClient.AccessorialsFor(attrs)
+}
+
+//AccessorialsFor returns the accessorials for a shipment's attributes using the client's
+//SetAllowUnverifiedAccessorials setting, see AccessorialsFor
+//This always returns ErrUnverifiedAccessorial for a needed code unless the client has opted in.
+func (c *Client) AccessorialsFor(attrs ShipmentAttributes) (items []RateQuoteAccessorialItem, err error) {
+	codes := []struct {
+		needed bool
+		code   AccessorialCode
+	}{
+		{attrs.ResidentialPickup, AccessorialResidentialPickup},
+		{attrs.ResidentialDelivery, AccessorialResidentialDelivery},
+		{attrs.LiftgatePickup, AccessorialLiftgatePickup},
+		{attrs.LiftgateDelivery, AccessorialLiftgateDelivery},
+		{attrs.InsidePickup, AccessorialInsidePickup},
+		{attrs.InsideDelivery, AccessorialInsideDelivery},
+		{attrs.LimitedAccessPickup, AccessorialLimitedAccessPickup},
+		{attrs.LimitedAccessDelivery, AccessorialLimitedAccessDelivery},
+		{attrs.ProtectFromFreeze, AccessorialProtectFromFreeze},
+		{attrs.Hazardous, AccessorialHazardous},
+		{attrs.DeliveryAppointment == AppointmentRequired, AccessorialAppointment},
+		{attrs.DeliveryAppointment == AppointmentRequested, AccessorialNotify},
+	}
+
+	for _, a := range codes {
+		if !a.needed {
+			continue
+		}
+
+		//make sure the code is one Ward knows about
+		if _, ok := accessorialCatalog[a.code]; !ok {
+			continue
+		}
+
+		//don't guess at codes Ward may not know, the charge would silently be left off the quote
+		if !c.accessorialAllowed(a.code) {
+			items = nil
+			err = errors.Wrap(ErrUnverifiedAccessorial, "ward.AccessorialsFor - "+string(a.code))
+			return
+		}
+
+		items = append(items, RateQuoteAccessorialItem{Code: a.code})
+	}
+
+	return
+}
+
+//ValidAccessorialCode checks if a code is one of the accessorial constants
+//This only catches typos, the constants themselves are unverified with Ward.
+func ValidAccessorialCode(code string) bool {
+	_, ok := accessorialCatalog[AccessorialCode(code)]
+	return ok
+}
+
+//Description returns a human readable description of the accessorial, or an empty string for unknown codes
+func (a AccessorialCode) Description() string {
+	return accessorialCatalog[a]
+}
+
+//CheckAccessorials checks that no accessorial code is listed more than once
+//Ward may charge a duplicated accessorial twice or reject the quote.  Codes are compared ignoring case and
+//surrounding spaces.  Use RemoveDuplicateAccessorials to drop duplicates instead.
+func (r RateQuoteRequest) CheckAccessorials() (issues []Issue) {
+	first := map[AccessorialCode]int{}
+	for i, a := range r.Request.Accessorials {
+		code := AccessorialCode(strings.ToUpper(strings.TrimSpace(string(a.Code))))
+		if j, ok := first[code]; ok {
+			issues = append(issues, Issue{
+				Field:   fmt.Sprintf("Request.Accessorials[%d].Code", i),
+				Message: fmt.Sprintf("%s is already listed at Request.Accessorials[%d]", code, j),
+			})
+			continue
+		}
+
+		first[code] = i
+	}
+
+	return
+}
+
+//RemoveDuplicateAccessorials removes any accessorial whose code is already listed, keeping the first
+func (r *RateQuoteRequestInner) RemoveDuplicateAccessorials() {
+	seen := map[AccessorialCode]bool{}
+	unique := r.Accessorials[:0]
+	for _, a := range r.Accessorials {
+		code := AccessorialCode(strings.ToUpper(strings.TrimSpace(string(a.Code))))
+		if seen[code] {
+			continue
+		}
+
+		seen[code] = true
+		unique = append(unique, a)
+	}
+
+	r.Accessorials = unique
+	return
+}
@@ -0,0 +1,199 @@
+package ward
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+//allowUnverified turns on unverified accessorials until the test ends
+func allowUnverified(t *testing.T) {
+	t.Helper()
+	SetAllowUnverifiedAccessorials(true)
+	t.Cleanup(func() { SetAllowUnverifiedAccessorials(false) })
+}
+
+func TestAccessorialsForUnverified(t *testing.T) {
+	items, err := AccessorialsFor(ShipmentAttributes{LiftgateDelivery: true})
+	if !errors.Is(err, ErrUnverifiedAccessorial) {
+		t.Fatalf("expected ErrUnverifiedAccessorial, got %v", err)
+	}
+	if len(items) != 0 {
+		t.Fatalf("expected no accessorials, got %v", items)
+	}
+
+	//nothing needed means nothing unverified is sent
+	items, err = AccessorialsFor(ShipmentAttributes{})
+	if err != nil || len(items) != 0 {
+		t.Fatalf("expected no accessorials and no error, got %v, %v", items, err)
+	}
+}
+
+func TestAccessorialsForAllowed(t *testing.T) {
+	allowUnverified(t)
+
+	items, err := AccessorialsFor(ShipmentAttributes{
+		ResidentialDelivery: true,
+		LiftgateDelivery:    true,
+		DeliveryAppointment: AppointmentRequired,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []RateQuoteAccessorialItem{
+		{Code: AccessorialResidentialDelivery},
+		{Code: AccessorialLiftgateDelivery},
+		{Code: AccessorialAppointment},
+	}
+	if !reflect.DeepEqual(items, expected) {
+		t.Fatalf("expected %v, got %v", expected, items)
+	}
+}
+
+func TestAccessorialsForPerClient(t *testing.T) {
+	c := NewClient()
+	c.SetAllowUnverifiedAccessorials(true)
+
+	items, err := c.AccessorialsFor(ShipmentAttributes{LiftgateDelivery: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := []RateQuoteAccessorialItem{{Code: AccessorialLiftgateDelivery}}; !reflect.DeepEqual(items, expected) {
+		t.Fatalf("expected %v, got %v", expected, items)
+	}
+
+	//the default client still refuses unverified codes
+	if _, err := AccessorialsFor(ShipmentAttributes{LiftgateDelivery: true}); !errors.Is(err, ErrUnverifiedAccessorial) {
+		t.Fatalf("expected ErrUnverifiedAccessorial, got %v", err)
+	}
+}
+
+func TestQuoteAppointmentAccessorial(t *testing.T) {
+	//not allowed, the accessorial is left off and validation warns about it
+	c := NewClient()
+	r := testRateQuoteRequest()
+	r.Request.DeliveryAppointment = AppointmentRequired
+	r.Request.applyAppointment(c)
+	if len(r.Request.Accessorials) != 0 {
+		t.Fatalf("expected no accessorials, got %v", r.Request.Accessorials)
+	}
+
+	warned := false
+	for _, i := range r.issuesFor(c) {
+		if i.Field == "Request.DeliveryAppointment" && i.Warning {
+			warned = true
+		}
+	}
+	if !warned {
+		t.Fatal("expected a warning about the delivery appointment")
+	}
+
+	//allowed, the accessorial is added once
+	c.SetAllowUnverifiedAccessorials(true)
+	r.Request.applyAppointment(c)
+	r.Request.applyAppointment(c)
+	expected := []RateQuoteAccessorialItem{{Code: AccessorialAppointment}}
+	if !reflect.DeepEqual(r.Request.Accessorials, expected) {
+		t.Fatalf("expected %v, got %v", expected, r.Request.Accessorials)
+	}
+}
+
+func TestValidAccessorialCode(t *testing.T) {
+	tests := []struct {
+		code  string
+		valid bool
+	}{
+		{"LIFTD", true},
+		{"APPT", true},
+		{"liftd", false},
+		{"", false},
+		{"NOPE", false},
+	}
+
+	for _, tt := range tests {
+		if got := ValidAccessorialCode(tt.code); got != tt.valid {
+			t.Errorf("ValidAccessorialCode(%q) = %t, expected %t", tt.code, got, tt.valid)
+		}
+	}
+}
+
+func TestAccessorialsUnverifiedByDefault(t *testing.T) {
+	for code := range accessorialCatalog {
+		if !verifiedAccessorials[code] && NewClient().accessorialAllowed(code) {
+			t.Errorf("%s is allowed but hasn't been verified with Ward", code)
+		}
+	}
+}
+
+func TestCheckAccessorials(t *testing.T) {
+	tests := []struct {
+		name   string
+		codes  []AccessorialCode
+		fields []string
+	}{
+		{"none", nil, nil},
+		{"unique", []AccessorialCode{AccessorialLiftgateDelivery, AccessorialResidentialDelivery}, nil},
+		{"duplicate", []AccessorialCode{AccessorialLiftgateDelivery, AccessorialResidentialDelivery, AccessorialLiftgateDelivery}, []string{"Request.Accessorials[2].Code"}},
+		{"case and spaces", []AccessorialCode{"liftd", " LIFTD "}, []string{"Request.Accessorials[1].Code"}},
+		{"three times", []AccessorialCode{"RESD", "RESD", "RESD"}, []string{"Request.Accessorials[1].Code", "Request.Accessorials[2].Code"}},
+	}
+
+	for _, tt := range tests {
+		var q RateQuoteRequest
+		for _, c := range tt.codes {
+			q.Request.Accessorials = append(q.Request.Accessorials, RateQuoteAccessorialItem{Code: c})
+		}
+
+		var fields []string
+		for _, i := range q.CheckAccessorials() {
+			fields = append(fields, i.Field)
+		}
+		if !reflect.DeepEqual(fields, tt.fields) {
+			t.Errorf("%s: expected issues for %v, got %v", tt.name, tt.fields, fields)
+		}
+	}
+}
+
+func TestRateQuoteDuplicateAccessorials(t *testing.T) {
+	allowUnverified(t)
+
+	q := testRateQuoteRequest()
+	q.Request.Accessorials = []RateQuoteAccessorialItem{{Code: AccessorialLiftgateDelivery}, {Code: AccessorialLiftgateDelivery}}
+
+	var v *ValidationError
+	if err := q.Validate(); !errors.As(err, &v) || len(v.Issues) != 1 || v.Issues[0].Field != "Request.Accessorials[1].Code" {
+		t.Fatalf("expected a duplicate accessorial issue, got %v", err)
+	}
+
+	q.Request.RemoveDuplicateAccessorials()
+	if err := q.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestRemoveDuplicateAccessorials(t *testing.T) {
+	r := RateQuoteRequestInner{Accessorials: []RateQuoteAccessorialItem{
+		{Code: AccessorialLiftgateDelivery},
+		{Code: AccessorialResidentialDelivery},
+		{Code: "liftd"},
+		{Code: AccessorialInsideDelivery},
+		{Code: AccessorialResidentialDelivery},
+	}}
+	r.RemoveDuplicateAccessorials()
+
+	expected := []RateQuoteAccessorialItem{
+		{Code: AccessorialLiftgateDelivery},
+		{Code: AccessorialResidentialDelivery},
+		{Code: AccessorialInsideDelivery},
+	}
+	if !reflect.DeepEqual(r.Accessorials, expected) {
+		t.Fatalf("expected %v, got %v", expected, r.Accessorials)
+	}
+
+	//a list without duplicates is left alone
+	r.RemoveDuplicateAccessorials()
+	if !reflect.DeepEqual(r.Accessorials, expected) {
+		t.Fatalf("expected %v, got %v", expected, r.Accessorials)
+	}
+}
@@ -0,0 +1,40 @@
+package ward
+
+import (
+	"os"
+	"strings"
+)
+
+//AccountEnv is the environment variable the account number is read from when a client is created
+const AccountEnv = "WARD_ACCOUNT"
+
+//accountFromEnv returns the account number from the environment, blank if it isn't set
+func accountFromEnv() string {
+	return strings.TrimSpace(os.Getenv(AccountEnv))
+}
+
+//SetAccount sets your Ward account number used for every pickup and rate quote
+//This fills in ShipperInfo.ShipperCode on pickups and Customer on rate quotes when they are blank, so a request
+//can still use a different account.  This defaults to the WARD_ACCOUNT environment variable.  Use a blank
+//account to stop filling these in.
+func SetAccount(account string) {
+	defaultClient.SetAccount(account)
+	return
+}
+
+//SetAccount sets your Ward account number used for every pickup and rate quote, see SetAccount
+func (c *Client) SetAccount(account string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.account = strings.TrimSpace(account)
+	return
+}
+
+//getAccount returns the account number for filling in requests
+func (c *Client) getAccount() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.account
+}
@@ -0,0 +1,25 @@
+package ward
+
+import (
+	"context"
+	"time"
+)
+
+//WardAPI is the calls made to Ward, implemented by *Client
+//This is the recommended way to integrate with this package.  Accept a WardAPI in your own code rather than
+//a *Client and you can swap in wardfake.FakeClient in your tests so they don't call Ward.
+type WardAPI interface {
+	RequestPickup(p *PickupRequest) (PickupRequestResponse, error)
+	RequestPickupContext(ctx context.Context, p *PickupRequest) (PickupRequestResponse, error)
+	RateQuote(p *RateQuoteRequest) (RateQuoteResponse, error)
+	RateQuoteContext(ctx context.Context, p *RateQuoteRequest) (RateQuoteResponse, error)
+	CancelPickup(confirmation string) (CancelPickupResponse, error)
+	TrackShipment(t TrackRequest) (TrackResponse, error)
+	GetQuote(quoteID string) (RateQuoteResponse, error)
+	SubmitBillOfLading(b *BillOfLadingRequest) (BillOfLadingResponse, error)
+	CheckServiceArea(originZip, destinationZip string) (ServiceArea, error)
+	Ping(ctx context.Context) (time.Duration, error)
+}
+
+//make sure Client always satisfies WardAPI
+var _ WardAPI = (*Client)(nil)
@@ -0,0 +1,151 @@
+package ward
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+//ErrDeliveryAppointmentInPast is returned when setting a delivery appointment for a day that has already passed
+var ErrDeliveryAppointmentInPast = errors.New("ward - delivery appointment date is in the past")
+
+//Appointment is the type of delivery appointment a consignee needs
+//Ward schedules and bills a required appointment differently than a requested one.
+type Appointment int
+
+//types of delivery appointments
+const (
+	AppointmentNone      Appointment = iota //no appointment, deliver whenever
+	AppointmentRequested                    //consignee prefers an appointment, delivery can still be made without one
+	AppointmentRequired                     //consignee must be called ahead and an appointment set before delivery
+)
+
+//appointmentRequestedNote is added to the pickup instructions when an appointment is requested but not required
+//Ward's pickup request only has a Y/N flag, which means required, so this is the only way to pass this along.
+const appointmentRequestedNote = "DELIVERY APPOINTMENT REQUESTED"
+
+//String returns a human readable name of the appointment type
+func (a Appointment) String() string {
+	switch a {
+	case AppointmentRequested:
+		return "requested"
+	case AppointmentRequired:
+		return "required"
+	default:
+		return "none"
+	}
+}
+
+//applyAppointment sets the Y/N flag and pickup instructions to match the type of delivery appointment
+//This is a no-op when DeliveryAppointment is not set so that callers setting DeliveryAppntFlag directly
+//still work.
+func (s *PickupRequestShipment) applyAppointment() {
+	switch s.DeliveryAppointment {
+	case AppointmentRequired:
+		s.DeliveryAppntFlag = "Y"
+
+	case AppointmentRequested:
+		s.DeliveryAppntFlag = "N"
+
+		//use the first open instruction line, if the note wasn't already added
+		instructions := []*string{
+			&s.PickupShipmentInstruction1,
+			&s.PickupShipmentInstruction2,
+			&s.PickupShipmentInstruction3,
+			&s.PickupShipmentInstruction4,
+		}
+		for _, i := range instructions {
+			if *i == appointmentRequestedNote {
+				return
+			}
+		}
+		for _, i := range instructions {
+			if *i == "" {
+				*i = appointmentRequestedNote
+				return
+			}
+		}
+	}
+}
+
+//applyAppointment adds the accessorial matching the type of delivery appointment, if it wasn't already added
+//Nothing is added unless the code is allowed by c, see SetAllowUnverifiedAccessorials.
+func (r *RateQuoteRequestInner) applyAppointment(c *Client) {
+	var code AccessorialCode
+	switch r.DeliveryAppointment {
+	case AppointmentRequired:
+		code = AccessorialAppointment
+	case AppointmentRequested:
+		code = AccessorialNotify
+	default:
+		return
+	}
+
+	if !c.accessorialAllowed(code) {
+		return
+	}
+
+	for _, a := range r.Accessorials {
+		if a.Code == code {
+			return
+		}
+	}
+
+	r.Accessorials = append(r.Accessorials, RateQuoteAccessorialItem{Code: code})
+}
+
+//SetDeliveryAppointment sets a required delivery appointment for a day, in the local timezone
+//This sets DeliveryAppointment to AppointmentRequired, the Y/N flag, and the date as mmddyyyy.  Only the day is
+//used.  Nothing is changed if the day has already passed, the current time comes from the Clock set with SetClock.
+func (s *PickupRequestShipment) SetDeliveryAppointment(t time.Time) (err error) {
+	current := now()
+	today := time.Date(current.Year(), current.Month(), current.Day(), 0, 0, 0, 0, time.Local)
+	day := t.In(time.Local)
+	if time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.Local).Before(today) {
+		err = ErrDeliveryAppointmentInPast
+		return
+	}
+
+	s.DeliveryAppointment = AppointmentRequired
+	s.DeliveryAppntFlag = YN(true)
+	s.DeliveryAppntDate = FormatPickupDate(t)
+	return
+}
+
+//CheckDeliveryAppointment checks that the delivery appointment flag and date agree
+//A required appointment needs a date that isn't in the past and a date needs the flag set.  The current time
+//comes from the Clock set with SetClock.
+func (s PickupRequestShipment) CheckDeliveryAppointment() (issues []Issue) {
+	return s.checkDeliveryAppointment(now())
+}
+
+//checkDeliveryAppointment checks the delivery appointment as of current, see CheckDeliveryAppointment
+func (s PickupRequestShipment) checkDeliveryAppointment(current time.Time) (issues []Issue) {
+	required := normalizeYN(s.DeliveryAppntFlag) == "Y" || s.DeliveryAppointment == AppointmentRequired
+	hasDate := s.DeliveryAppntDate != ""
+
+	if !required {
+		if hasDate {
+			issues = append(issues, Issue{Field: "Shipment.DeliveryAppntFlag", Message: "must be Y when a delivery appointment date is given"})
+		}
+		return
+	}
+
+	if !hasDate {
+		issues = append(issues, Issue{Field: "Shipment.DeliveryAppntDate", Message: "is required when a delivery appointment is required"})
+		return
+	}
+
+	date, err := parsePickupDate(s.DeliveryAppntDate, current.Location())
+	if err != nil {
+		issues = append(issues, Issue{Field: "Shipment.DeliveryAppntDate", Message: "must be mmddyyyy"})
+		return
+	}
+
+	today := time.Date(current.Year(), current.Month(), current.Day(), 0, 0, 0, 0, current.Location())
+	if date.Before(today) {
+		issues = append(issues, Issue{Field: "Shipment.DeliveryAppntDate", Message: "is in the past"})
+	}
+
+	return
+}
@@ -0,0 +1,87 @@
+package ward
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+//freezeDefaultClock sets the default client's clock to frozenNow until the test ends
+func freezeDefaultClock(t *testing.T) {
+	t.Helper()
+	SetClock(frozenClock(frozenNow))
+	t.Cleanup(func() { SetClock(nil) })
+}
+
+func TestSetDeliveryAppointment(t *testing.T) {
+	freezeDefaultClock(t)
+
+	tests := []struct {
+		name string
+		day  time.Time
+		date string
+	}{
+		{"today", frozenNow, "03042024"},
+		{"earlier today", time.Date(2024, 3, 4, 1, 0, 0, 0, time.Local), "03042024"},
+		{"future", time.Date(2024, 3, 7, 15, 0, 0, 0, time.Local), "03072024"},
+	}
+
+	for _, tt := range tests {
+		var s PickupRequestShipment
+		if err := s.SetDeliveryAppointment(tt.day); err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+		if s.DeliveryAppointment != AppointmentRequired || s.DeliveryAppntFlag != "Y" || s.DeliveryAppntDate != tt.date {
+			t.Fatalf("%s: expected a required appointment on %s, got %+v", tt.name, tt.date, s)
+		}
+		if issues := s.CheckDeliveryAppointment(); len(issues) != 0 {
+			t.Fatalf("%s: expected no issues, got %v", tt.name, issues)
+		}
+	}
+}
+
+func TestSetDeliveryAppointmentInPast(t *testing.T) {
+	freezeDefaultClock(t)
+
+	var s PickupRequestShipment
+	err := s.SetDeliveryAppointment(frozenNow.AddDate(0, 0, -1))
+	if !errors.Is(err, ErrDeliveryAppointmentInPast) {
+		t.Fatalf("expected ErrDeliveryAppointmentInPast, got %v", err)
+	}
+	if !reflect.DeepEqual(s, PickupRequestShipment{}) {
+		t.Fatalf("expected nothing to be set, got %+v", s)
+	}
+}
+
+func TestCheckDeliveryAppointment(t *testing.T) {
+	freezeDefaultClock(t)
+
+	tests := []struct {
+		name  string
+		s     PickupRequestShipment
+		field string
+	}{
+		{"none", PickupRequestShipment{}, ""},
+		{"not required", PickupRequestShipment{DeliveryAppntFlag: "N"}, ""},
+		{"future", PickupRequestShipment{DeliveryAppntFlag: "Y", DeliveryAppntDate: "03072024"}, ""},
+		{"missing date", PickupRequestShipment{DeliveryAppntFlag: "Y"}, "Shipment.DeliveryAppntDate"},
+		{"required without flag", PickupRequestShipment{DeliveryAppointment: AppointmentRequired}, "Shipment.DeliveryAppntDate"},
+		{"past date", PickupRequestShipment{DeliveryAppntFlag: "Y", DeliveryAppntDate: "03012024"}, "Shipment.DeliveryAppntDate"},
+		{"malformed date", PickupRequestShipment{DeliveryAppntFlag: "Y", DeliveryAppntDate: "tomorrow"}, "Shipment.DeliveryAppntDate"},
+		{"date without flag", PickupRequestShipment{DeliveryAppntDate: "03072024"}, "Shipment.DeliveryAppntFlag"},
+	}
+
+	for _, tt := range tests {
+		issues := tt.s.CheckDeliveryAppointment()
+		if tt.field == "" {
+			if len(issues) != 0 {
+				t.Errorf("%s: expected no issues, got %v", tt.name, issues)
+			}
+			continue
+		}
+		if len(issues) != 1 || issues[0].Field != tt.field {
+			t.Errorf("%s: expected one issue for %s, got %v", tt.name, tt.field, issues)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package ward
+
+import (
+	"io/ioutil"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+//RequestSigner computes a signature for a request and attaches it, usually as a header
+//body is exactly what will be sent, nil for requests without a body.  Returning an error stops the request
+//from being sent.
+type RequestSigner func(req *http.Request, body []byte) error
+
+//SetAPIKey sets a static header, such as an api key, added to every request
+//Ward doesn't require any authentication itself, this is for gateways or proxies in front of Ward that do.
+//Use a blank header to stop sending it.
+func SetAPIKey(header, key string) {
+	defaultClient.SetAPIKey(header, key)
+	return
+}
+
+//SetAPIKey sets a static header added to every request, see SetAPIKey
+func (c *Client) SetAPIKey(header, key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.apiKeyHeader = header
+	c.apiKey = key
+	return
+}
+
+//SetRequestSigner sets a function to sign every request just before it is sent
+//This is called after all other headers are set.  Use nil to stop signing requests.
+func SetRequestSigner(s RequestSigner) {
+	defaultClient.SetRequestSigner(s)
+	return
+}
+
+//SetRequestSigner sets a function to sign every request, see SetRequestSigner
+func (c *Client) SetRequestSigner(s RequestSigner) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.requestSigner = s
+	return
+}
+
+//authenticate adds the api key header and signature to a request
+func (c *Client) authenticate(req *http.Request) (err error) {
+	c.mu.RLock()
+	header, key, signer := c.apiKeyHeader, c.apiKey, c.requestSigner
+	c.mu.RUnlock()
+
+	if header != "" {
+		req.Header.Set(header, key)
+	}
+
+	if signer == nil {
+		return
+	}
+
+	//get a copy of the body for signing without consuming the one that will be sent
+	var body []byte
+	if req.GetBody != nil {
+		rc, bodyErr := req.GetBody()
+		if bodyErr != nil {
+			err = errors.Wrap(bodyErr, "ward.authenticate - could not read body to sign")
+			return
+		}
+		defer rc.Close()
+
+		body, err = ioutil.ReadAll(rc)
+		if err != nil {
+			err = errors.Wrap(err, "ward.authenticate - could not read body to sign")
+			return
+		}
+	}
+
+	err = signer(req, body)
+	if err != nil {
+		err = errors.Wrap(err, "ward.authenticate - could not sign request")
+		return
+	}
+
+	return
+}
@@ -0,0 +1,32 @@
+package ward
+
+import "context"
+
+//RateQuoteBatchResult is the outcome of one rate quote in a batch
+type RateQuoteBatchResult struct {
+	Response RateQuoteResponse
+	Err      error
+}
+
+//RateQuoteBatch gets many rate quotes concurrently using the default client
+func RateQuoteBatch(ctx context.Context, reqs []RateQuoteRequest, concurrency int) []RateQuoteBatchResult {
+	return defaultClient.RateQuoteBatch(ctx, reqs, concurrency)
+}
+
+//RateQuoteBatch gets many rate quotes concurrently, with at most concurrency quotes in progress at once
+//Results are in the same order as the requests and a failed quote doesn't stop the others.  Cancelling ctx
+//stops the quotes in progress and any quotes not yet started get ctx's error.  A concurrency of zero or less
+//uses one worker per cpu.
+func (c *Client) RateQuoteBatch(ctx context.Context, reqs []RateQuoteRequest, concurrency int) (results []RateQuoteBatchResult) {
+	results = make([]RateQuoteBatchResult, len(reqs))
+	runPool(len(reqs), concurrency, func(i int) {
+		if err := ctx.Err(); err != nil {
+			results[i].Err = err
+			return
+		}
+
+		results[i].Response, results[i].Err = c.RateQuoteContext(ctx, &reqs[i])
+	})
+
+	return
+}
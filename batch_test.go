@@ -0,0 +1,118 @@
+package ward
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+//customerPattern finds the customer element in a request or response
+var customerPattern = regexp.MustCompile(`<Customer>[^<]*</Customer>`)
+
+//TestRateQuoteBatch checks results come back in request order with failures kept to their own request, run
+//with -race
+func TestRateQuoteBatch(t *testing.T) {
+	//echo the customer back so each response can be matched to its request
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		customer := customerPattern.FindString(string(body))
+		w.Write([]byte(customerPattern.ReplaceAllString(quoteSuccessXML, customer)))
+	})
+
+	reqs := make([]RateQuoteRequest, 10)
+	for i := range reqs {
+		reqs[i] = testRateQuoteRequest()
+		reqs[i].Request.Customer = strings.Repeat("9", i+1)
+	}
+
+	//an invalid request fails on its own
+	reqs[3].Request.Details = nil
+
+	results := c.RateQuoteBatch(context.Background(), reqs, 3)
+	if len(results) != len(reqs) {
+		t.Fatalf("expected %d results, got %d", len(reqs), len(results))
+	}
+
+	for i, res := range results {
+		if i == 3 {
+			var v *ValidationError
+			if !errors.As(res.Err, &v) {
+				t.Errorf("expected a validation error for request 3, got %v", res.Err)
+			}
+			continue
+		}
+
+		if res.Err != nil {
+			t.Errorf("request %d: unexpected error: %v", i, res.Err)
+		}
+		if got := res.Response.CreateResult.Customer; got != reqs[i].Request.Customer {
+			t.Errorf("request %d: got the response for customer %s", i, got)
+		}
+	}
+
+}
+
+func TestRateQuoteBatchConcurrency(t *testing.T) {
+	var inFlight, peak int32
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		w.Write([]byte(quoteSuccessXML))
+	})
+
+	reqs := make([]RateQuoteRequest, 12)
+	for i := range reqs {
+		reqs[i] = testRateQuoteRequest()
+	}
+
+	c.RateQuoteBatch(context.Background(), reqs, 2)
+	if peak > 2 {
+		t.Fatalf("expected at most 2 quotes at once, got %d", peak)
+	}
+}
+
+func TestRateQuoteBatchCancelled(t *testing.T) {
+	c, _ := newTestClient(t, respond(http.StatusOK, quoteSuccessXML))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	reqs := []RateQuoteRequest{testRateQuoteRequest(), testRateQuoteRequest()}
+	for i, res := range c.RateQuoteBatch(ctx, reqs, 1) {
+		if !errors.Is(res.Err, context.Canceled) {
+			t.Errorf("request %d: expected context.Canceled, got %v", i, res.Err)
+		}
+	}
+}
+
+func TestRunPool(t *testing.T) {
+	//every index is called exactly once
+	counts := make([]int32, 100)
+	runPool(len(counts), 7, func(i int) {
+		atomic.AddInt32(&counts[i], 1)
+	})
+	for i, n := range counts {
+		if n != 1 {
+			t.Fatalf("index %d called %d times", i, n)
+		}
+	}
+
+	//nothing to do and a default concurrency
+	runPool(0, 0, func(i int) {
+		t.Fatal("expected no calls")
+	})
+}
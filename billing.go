@@ -0,0 +1,44 @@
+package ward
+
+import "strings"
+
+//BillingTerms is who pays the freight charges on a rate quote
+type BillingTerms string
+
+//billing terms Ward rates for
+//Ward's documentation doesn't list these, so if Ward rejects a quote's terms check the values with Ward.
+const (
+	BillingTermsPrepaid    BillingTerms = "P" //shipper pays
+	BillingTermsCollect    BillingTerms = "C" //consignee pays
+	BillingTermsThirdParty BillingTerms = "T" //someone other than the shipper or consignee pays
+)
+
+//ValidBillingTerms checks if the billing terms are blank or one of the BillingTerms constants, ignoring case
+//Blank terms are sent to Ward as is so Ward's default for the account is used.
+func ValidBillingTerms(b BillingTerms) bool {
+	switch normalizeBillingTerms(b) {
+	case "", BillingTermsPrepaid, BillingTermsCollect, BillingTermsThirdParty:
+		return true
+	default:
+		return false
+	}
+}
+
+//normalizeBillingTerms returns the uppercase billing terms
+func normalizeBillingTerms(b BillingTerms) BillingTerms {
+	return BillingTerms(strings.ToUpper(strings.TrimSpace(string(b))))
+}
+
+//String returns a human readable name of the billing terms
+func (b BillingTerms) String() string {
+	switch normalizeBillingTerms(b) {
+	case BillingTermsPrepaid:
+		return "prepaid"
+	case BillingTermsCollect:
+		return "collect"
+	case BillingTermsThirdParty:
+		return "third party"
+	default:
+		return string(b)
+	}
+}
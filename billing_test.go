@@ -0,0 +1,72 @@
+package ward
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidBillingTerms(t *testing.T) {
+	tests := []struct {
+		terms BillingTerms
+		valid bool
+	}{
+		{"", true},
+		{BillingTermsPrepaid, true},
+		{BillingTermsCollect, true},
+		{BillingTermsThirdParty, true},
+		{"c", true},
+		{" t ", true},
+		{"X", false},
+		{"PP", false},
+	}
+
+	for _, tt := range tests {
+		if got := ValidBillingTerms(tt.terms); got != tt.valid {
+			t.Errorf("ValidBillingTerms(%q) = %v, expected %v", tt.terms, got, tt.valid)
+		}
+	}
+}
+
+func TestBillingTermsString(t *testing.T) {
+	tests := []struct {
+		terms BillingTerms
+		str   string
+	}{
+		{"", ""},
+		{BillingTermsPrepaid, "prepaid"},
+		{"c", "collect"},
+		{BillingTermsThirdParty, "third party"},
+		{"X", "X"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.terms.String(); got != tt.str {
+			t.Errorf("%q.String() = %q, expected %q", string(tt.terms), got, tt.str)
+		}
+	}
+}
+
+func TestBuildRateQuoteXMLBillingTerms(t *testing.T) {
+	tests := []struct {
+		terms    BillingTerms
+		expected string
+	}{
+		{"", "<BillingTerms></BillingTerms>"},
+		{"c", "<BillingTerms>C</BillingTerms>"},
+		{" t ", "<BillingTerms>T</BillingTerms>"},
+		{BillingTermsPrepaid, "<BillingTerms>P</BillingTerms>"},
+	}
+
+	for _, tt := range tests {
+		q := testRateQuoteRequest()
+		q.Request.BillingTerms = tt.terms
+
+		xmlString, err := NewClient().BuildRateQuoteXML(&q)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", string(tt.terms), err)
+		}
+		if !strings.Contains(xmlString, tt.expected) {
+			t.Errorf("%q: expected %s in\n%s", string(tt.terms), tt.expected, xmlString)
+		}
+	}
+}
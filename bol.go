@@ -0,0 +1,265 @@
+package ward
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+//EndpointBillOfLading is the name of the bill of lading endpoint, used with SetEndpointConfig
+const EndpointBillOfLading = "billoflading"
+
+//ErrNoBillOfLadingURL is returned when submitting a bill of lading before the url is set
+//Ward's documentation for the pickup and rate quote services does not include a bill of lading service, so the
+//url of the service your Ward account has access to must be set with SetBillOfLadingURLs.
+var ErrNoBillOfLadingURL = errors.New("ward - bill of lading url is not set")
+
+//SetBillOfLadingURLs sets the test and production urls of Ward's bill of lading service
+func SetBillOfLadingURLs(test, production string) {
+	defaultClient.SetBillOfLadingURLs(test, production)
+	return
+}
+
+//SetBillOfLadingURLs sets the test and production urls of Ward's bill of lading service, see SetBillOfLadingURLs
+func (c *Client) SetBillOfLadingURLs(test, production string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.bolTestURL = test
+	c.bolProductionURL = production
+	return
+}
+
+//bolURL returns the bill of lading url for the current mode
+func (c *Client) bolURL() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.production {
+		return c.bolProductionURL
+	}
+
+	return c.bolTestURL
+}
+
+//BillOfLadingRequest is the data to create a bill of lading with
+type BillOfLadingRequest struct {
+	Customer            string                     `xml:"Customer"`     //your Ward account number
+	BillingTerms        BillingTerms               `xml:"BillingTerms"` //who pays, see the BillingTerms constants, blank uses Ward's default
+	PickupConfirmation  string                     `xml:"PickupConfirmation,omitempty"`
+	Reference           string                     `xml:"Reference,omitempty"` //your po or order number
+	Shipper             BillOfLadingParty          `xml:"Shipper"`
+	Consignee           BillOfLadingParty          `xml:"Consignee"`
+	Items               []BillOfLadingItem         `xml:"Items>Item"`
+	Accessorials        []RateQuoteAccessorialItem `xml:"Accessorials>AccessorialItem"`
+	SpecialInstructions string                     `xml:"SpecialInstructions,omitempty"`
+}
+
+//BillOfLadingParty is the shipper or consignee on a bill of lading
+type BillOfLadingParty struct {
+	Name             string `xml:"Name"`
+	Address1         string `xml:"Address1"`
+	Address2         string `xml:"Address2"`
+	City             string `xml:"City"`
+	State            string `xml:"State"` //two char code
+	Zipcode          string `xml:"Zipcode"`
+	Country          string `xml:"Country,omitempty"` //US or CA, blank is US
+	ContactName      string `xml:"ContactName"`
+	ContactTelephone string `xml:"ContactTelephone"` //xxxxxxxxxx, only numbers
+}
+
+//BillOfLadingItem is one line of freight on a bill of lading
+type BillOfLadingItem struct {
+	Pieces      uint         `xml:"Pieces"`      // > 0
+	PackageCode string       `xml:"PackageCode"` //code per Ward's website
+	Weight      uint         `xml:"Weight"`      //lbs
+	Class       FreightClass `xml:"Class"`
+	Description string       `xml:"Description"`
+	Hazardous   string       `xml:"Hazardous"` //Y or N
+}
+
+//BillOfLadingResponse is the data returned when a bill of lading is created
+type BillOfLadingResponse struct {
+	XMLName      xml.Name                   `xml:"Envelope" json:"-"`                                    //dont need "soap12"
+	CreateResult BillOfLadingResponseResult `xml:"Body>CreateResponse>CreateResult" json:"createResult"` //dont need "soap12"
+
+	Duration time.Duration `xml:"-" json:"duration"` //how long the call to Ward took, including any retries
+}
+
+//BillOfLadingResponseResult is the actual body of the bill of lading response
+type BillOfLadingResponseResult struct {
+	BOLNumber   string `xml:"BOLNumber" json:"bolNumber"`
+	ProNumber   string `xml:"ProNumber" json:"proNumber"`
+	DocumentURL string `xml:"DocumentURL" json:"documentUrl"` //link to the pdf, if Ward hosts it
+	Document    string `xml:"Document" json:"document"`       //base64 encoded pdf, if Ward sent it inline
+	Message     string `xml:"Message" json:"message"`
+
+	Timestamp time.Time `xml:"-" json:"timestamp"` //ward's server time from the response, zero if Ward didn't send one
+}
+
+//PDF returns the decoded bill of lading document
+//This is empty if Ward only sent a DocumentURL.
+func (r BillOfLadingResponseResult) PDF() (pdf []byte, err error) {
+	if r.Document == "" {
+		return
+	}
+
+	pdf, err = base64.StdEncoding.DecodeString(strings.TrimSpace(r.Document))
+	if err != nil {
+		err = errors.Wrap(err, "ward.PDF - could not decode document")
+		return
+	}
+
+	return
+}
+
+//Validate checks a bill of lading request for missing or malformed data without making any network calls
+//This returns a *ValidationError listing every problem found, or nil if the request looks ok.
+func (b *BillOfLadingRequest) Validate() error {
+	return validationError(b.issues())
+}
+
+//issues returns every problem found with a bill of lading request
+func (b *BillOfLadingRequest) issues() (issues []Issue) {
+	if strings.TrimSpace(b.Customer) == "" {
+		issues = append(issues, Issue{Field: "Customer", Message: "is required"})
+	}
+	if !ValidBillingTerms(b.BillingTerms) {
+		issues = append(issues, Issue{Field: "BillingTerms", Message: "must be prepaid (P), collect (C), or third party (T)"})
+	}
+
+	parties := []struct {
+		field string
+		party BillOfLadingParty
+	}{
+		{"Shipper", b.Shipper},
+		{"Consignee", b.Consignee},
+	}
+	for _, p := range parties {
+		issues = append(issues, p.party.issues(p.field)...)
+	}
+
+	if len(b.Items) == 0 {
+		issues = append(issues, Issue{Field: "Items", Message: "must have at least one item"})
+	}
+	for i, item := range b.Items {
+		if item.Pieces == 0 {
+			issues = append(issues, Issue{Field: fmt.Sprintf("Items[%d].Pieces", i), Message: "must be greater than zero"})
+		}
+		if item.Weight == 0 {
+			issues = append(issues, Issue{Field: fmt.Sprintf("Items[%d].Weight", i), Message: "must be greater than zero"})
+		}
+		if !item.Class.Valid() {
+			issues = append(issues, Issue{Field: fmt.Sprintf("Items[%d].Class", i), Message: "must be a standard freight class"})
+		}
+		if strings.TrimSpace(item.Description) == "" {
+			issues = append(issues, Issue{Field: fmt.Sprintf("Items[%d].Description", i), Message: "is required"})
+		}
+		if !validYN(item.Hazardous) {
+			issues = append(issues, Issue{Field: fmt.Sprintf("Items[%d].Hazardous", i), Message: "must be Y or N"})
+		}
+	}
+
+	return
+}
+
+//issues returns every problem found with a shipper or consignee, field is the name of the party
+func (p BillOfLadingParty) issues(field string) (issues []Issue) {
+	required := []struct {
+		field string
+		value string
+	}{
+		{"Name", p.Name},
+		{"Address1", p.Address1},
+		{"City", p.City},
+		{"State", p.State},
+		{"Zipcode", p.Zipcode},
+	}
+	for _, r := range required {
+		if strings.TrimSpace(r.value) == "" {
+			issues = append(issues, Issue{Field: field + "." + r.field, Message: "is required"})
+		}
+	}
+
+	issues = append(issues, addressIssues(field+".", p.Country, p.State, p.Zipcode)...)
+	if p.ContactTelephone != "" && !isTelephone(p.ContactTelephone) {
+		issues = append(issues, Issue{Field: field + ".ContactTelephone", Message: "must be ten digits, only numbers"})
+	}
+
+	return
+}
+
+//SubmitBillOfLading creates a bill of lading using the default client
+func (b *BillOfLadingRequest) SubmitBillOfLading() (responseData BillOfLadingResponse, err error) {
+	return defaultClient.SubmitBillOfLading(b)
+}
+
+//SubmitBillOfLading performs the call to the Ward API to create a bill of lading
+func (c *Client) SubmitBillOfLading(b *BillOfLadingRequest) (responseData BillOfLadingResponse, err error) {
+	//track how long the call takes
+	start := c.now()
+	defer func() {
+		responseData.Duration = c.since(start)
+		c.observe(EndpointBillOfLading, responseData.Duration, err)
+	}()
+
+	endpointURL := c.bolURL()
+	if endpointURL == "" {
+		err = ErrNoBillOfLadingURL
+		return
+	}
+
+	//make sure the flags are an uppercase Y or N
+	for i := range b.Items {
+		b.Items[i].Hazardous = normalizeYN(b.Items[i].Hazardous)
+	}
+
+	//check for malformed fields before making a round trip to Ward
+	err = b.Validate()
+	if err != nil {
+		err = errors.Wrap(err, "ward.SubmitBillOfLading - invalid request")
+		return
+	}
+
+	//convert the bill of lading request to an xml
+	xmlString, err := c.buildSOAPBody(b)
+	if err != nil {
+		err = errors.Wrap(err, "ward.SubmitBillOfLading - could not marshal xml")
+		return
+	}
+
+	//never retry, a request that reached Ward but whose response was lost would create a duplicate bill of lading
+	body, _, err := c.doRequest(context.Background(), EndpointBillOfLading, endpointURL, xmlString, false)
+	if err != nil {
+		err = errors.Wrap(err, "ward.SubmitBillOfLading - could not make request")
+		return
+	}
+
+	err = unmarshalResponse(body, &responseData)
+	if err != nil {
+		err = errors.Wrap(err, "ward.SubmitBillOfLading - could not read response")
+		return
+	}
+
+	//keep Ward's timestamp for auditing
+	responseData.CreateResult.Timestamp = parseServerTimestamp(body)
+
+	//no bol number means the bill of lading wasn't created
+	if responseData.CreateResult.BOLNumber == "" {
+		msg := strings.TrimSpace(responseData.CreateResult.Message)
+		if msg == "" {
+			msg = "no bol number returned"
+		}
+
+		err = errors.New("ward.SubmitBillOfLading - bill of lading not created: " + msg)
+		c.logf("%s\n%s", err, body)
+		return
+	}
+
+	return
+}
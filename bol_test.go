@@ -0,0 +1,77 @@
+package ward
+
+import "testing"
+
+//testBillOfLadingRequest returns a bill of lading request that passes validation
+func testBillOfLadingRequest() BillOfLadingRequest {
+	return BillOfLadingRequest{
+		Customer:     "12345",
+		BillingTerms: BillingTermsPrepaid,
+		Shipper: BillOfLadingParty{
+			Name:     "ACME WIDGETS",
+			Address1: "100 MAIN ST",
+			City:     "PITTSBURGH",
+			State:    "PA",
+			Zipcode:  "15222",
+		},
+		Consignee: BillOfLadingParty{
+			Name:     "BETA SUPPLY",
+			Address1: "200 OAK AVE",
+			City:     "CLEVELAND",
+			State:    "OH",
+			Zipcode:  "44101",
+		},
+		Items: []BillOfLadingItem{
+			{Pieces: 2, Weight: 1200, Class: Class70, Description: "WIDGETS", Hazardous: "N"},
+		},
+	}
+}
+
+//bolSuccessXML is a response to a bill of lading that was created
+const bolSuccessXML = `<?xml version="1.0" encoding="utf-8"?>
+<soap:Envelope xmlns:soap="http://www.w3.org/2003/05/soap-envelope"><soap:Body><CreateResponse><CreateResult>
+<BOLNumber>BOL555</BOLNumber><ProNumber>123456789</ProNumber><Message></Message>
+</CreateResult></CreateResponse></soap:Body></soap:Envelope>`
+
+func TestBillOfLadingValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(b *BillOfLadingRequest)
+		field  string
+	}{
+		{"valid", func(b *BillOfLadingRequest) {}, ""},
+		{"blank terms", func(b *BillOfLadingRequest) { b.BillingTerms = "" }, ""},
+		{"lowercase terms", func(b *BillOfLadingRequest) { b.BillingTerms = "c" }, ""},
+		{"bad terms", func(b *BillOfLadingRequest) { b.BillingTerms = "X" }, "BillingTerms"},
+		{"bad state", func(b *BillOfLadingRequest) { b.Shipper.State = "PENN" }, "Shipper.State"},
+		{"bad zip", func(b *BillOfLadingRequest) { b.Consignee.Zipcode = "441" }, "Consignee.Zipcode"},
+		{"bad country", func(b *BillOfLadingRequest) { b.Consignee.Country = "MX" }, "Consignee.Country"},
+		{"canada", func(b *BillOfLadingRequest) {
+			b.Consignee.Country = CountryCA
+			b.Consignee.State = "ON"
+			b.Consignee.Zipcode = "M5V 2T6"
+		}, ""},
+		{"canadian zip", func(b *BillOfLadingRequest) {
+			b.Consignee.Country = CountryCA
+			b.Consignee.State = "ON"
+		}, "Consignee.Zipcode"},
+		{"bad telephone", func(b *BillOfLadingRequest) { b.Shipper.ContactTelephone = "555-0100" }, "Shipper.ContactTelephone"},
+	}
+
+	for _, tt := range tests {
+		b := testBillOfLadingRequest()
+		tt.modify(&b)
+
+		issues := b.issues()
+		if tt.field == "" {
+			if len(issues) != 0 {
+				t.Errorf("%s: expected no issues, got %v", tt.name, issues)
+			}
+			continue
+		}
+
+		if len(issues) != 1 || issues[0].Field != tt.field {
+			t.Errorf("%s: expected one issue for %s, got %v", tt.name, tt.field, issues)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package ward
+
+import "github.com/pkg/errors"
+
+//BuildPickupXML returns the xml RequestPickup would send using the default client, without sending it
+func (p *PickupRequest) BuildPickupXML() (xmlString string, err error) {
+	return defaultClient.BuildPickupXML(p)
+}
+
+//BuildPickupXML returns the xml RequestPickup would send, without sending it
+//The request is filled in and validated the same way as when it is sent, so the result is byte for byte what
+//would be posted to Ward.  Use this to log or snapshot requests.  A copy of the request is filled in, p is not
+//changed.
+func (c *Client) BuildPickupXML(p *PickupRequest) (xmlString string, err error) {
+	return c.buildPickupXML(*p)
+}
+
+//buildPickupXML fills in, validates, and marshals a copy of a pickup request
+func (c *Client) buildPickupXML(p PickupRequest) (xmlString string, err error) {
+	//set the appointment flag and notes based on the type of appointment
+	p.Shipment.applyAppointment()
+
+	//make sure the flags are an uppercase Y or N, Ward rejects "y" or "yes"
+	p.Shipment.normalizeFlags()
+
+	//Ward only takes pounds
+	p.Shipment.normalizeWeight()
+
+	//attribute the request to the requestor set for all requests
+	p.applyRequestor(c.getRequestor())
+
+	//use the account set for all requests if one wasn't given
+	if p.ShipperInfo.ShipperCode == "" {
+		p.ShipperInfo.ShipperCode = c.getAccount()
+	}
+
+	//make sure the pickup date is mmddyyyy
+	p.ShipperInfo.normalizePickupDate()
+
+	//fill in any missing cities and states from the zip codes
+	err = p.resolveZips(c.getZipResolver())
+	if err != nil {
+		err = errors.Wrap(err, "ward.BuildPickupXML - could not resolve zip code")
+		return
+	}
+
+	//check for malformed fields before making a round trip to Ward
+	//use this client's clock so the pickup date is checked against the same time everything else uses
+	err = validationError(p.issuesFor(c))
+	if err != nil {
+		err = errors.Wrap(err, "ward.BuildPickupXML - invalid request")
+		return
+	}
+
+	//make sure the insured amount is an exact dollar amount
+	err = p.Shipment.normalizeInsuredAmount()
+	if err != nil {
+		err = errors.Wrap(err, "ward.BuildPickupXML - invalid insured amount")
+		return
+	}
+
+	//convert the pickup request to an xml
+	xmlString, err = c.buildSOAPBody(p.envelopeBody())
+	if err != nil {
+		err = errors.Wrap(err, "ward.BuildPickupXML - could not marshal xml")
+		return
+	}
+
+	return
+}
+
+//BuildRateQuoteXML returns the xml RateQuote would send using the default client, without sending it
+func (p *RateQuoteRequest) BuildRateQuoteXML() (xmlString string, err error) {
+	return defaultClient.BuildRateQuoteXML(p)
+}
+
+//BuildRateQuoteXML returns the xml RateQuote would send, without sending it, see BuildPickupXML
+//A copy of the request is filled in, p is not changed.
+func (c *Client) BuildRateQuoteXML(p *RateQuoteRequest) (xmlString string, err error) {
+	return c.buildRateQuoteXML(*p)
+}
+
+//buildRateQuoteXML fills in, validates, and marshals a copy of a rate quote request
+func (c *Client) buildRateQuoteXML(p RateQuoteRequest) (xmlString string, err error) {
+	//copy the slices too since the detail weights are converted and accessorials may be appended
+	p.Request.Details = append([]RateQuoteDetailItem(nil), p.Request.Details...)
+	p.Request.Accessorials = append([]RateQuoteAccessorialItem(nil), p.Request.Accessorials...)
+
+	//add the accessorial for the type of appointment
+	p.Request.applyAppointment(c)
+
+	//Ward only takes pounds
+	p.Request.normalizeWeights()
+
+	//use the account set for all requests if one wasn't given
+	if p.Request.Customer == "" {
+		p.Request.Customer = c.getAccount()
+	}
+
+	//make sure the billing terms are uppercase, blank terms are left for Ward to default
+	if ValidBillingTerms(p.Request.BillingTerms) {
+		p.Request.BillingTerms = normalizeBillingTerms(p.Request.BillingTerms)
+	}
+
+	//fill in any missing cities and states from the zip codes
+	err = p.Request.resolveZips(c.getZipResolver())
+	if err != nil {
+		err = errors.Wrap(err, "ward.BuildRateQuoteXML - could not resolve zip code")
+		return
+	}
+
+	//the pallet count should be the total of the detail pieces
+	c.mu.RLock()
+	autoPalletCount := c.autoPalletCount
+	c.mu.RUnlock()
+	if autoPalletCount && p.Request.PalletCount == 0 {
+		p.Request.PalletCount = p.Request.TotalPieces()
+	}
+
+	//check for malformed fields before making a round trip to Ward
+	err = validationError(p.issuesFor(c))
+	if err != nil {
+		err = errors.Wrap(err, "ward.BuildRateQuoteXML - invalid request")
+		return
+	}
+
+	//convert the rate quote request to an xml
+	xmlString, err = c.buildSOAPBody(p.Request)
+	if err != nil {
+		err = errors.Wrap(err, "ward.BuildRateQuoteXML - could not marshal xml")
+		return
+	}
+
+	return
+}
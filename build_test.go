@@ -0,0 +1,68 @@
+package ward
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBuildPickupXMLDoesNotChangeRequest(t *testing.T) {
+	c := NewClient(WithClock(frozenClock(frozenNow)))
+	c.SetAccount("12345")
+	c.SetRequestor(Requestor{User: "API"})
+
+	p := testPickupRequest()
+	p.ShipperInfo.ShipperCode = ""
+	p.ShipperInfo.PickupDate = frozenPickupDate
+	p.Shipment.Hazardous = "no"
+	p.Shipment.Weight = 500
+	p.Shipment.WeightUnit = Kilograms
+	p.Shipment.DeliveryAppointment = AppointmentRequested
+	original := p
+
+	if _, err := c.BuildPickupXML(&p); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(p, original) {
+		t.Fatalf("request was changed\ngot:      %+v\nexpected: %+v", p, original)
+	}
+}
+
+func TestBuildRateQuoteXMLDoesNotChangeRequest(t *testing.T) {
+	c := NewClient()
+	c.SetAccount("12345")
+	c.SetAllowUnverifiedAccessorials(true)
+
+	q := testRateQuoteRequest()
+	q.Request.Customer = ""
+	q.Request.BillingTerms = "c"
+	q.Request.Details[0].Weight = 500
+	q.Request.Details[0].WeightUnit = Kilograms
+	q.Request.DeliveryAppointment = AppointmentRequired
+
+	//spare capacity would let an append write into the caller's array
+	q.Request.Accessorials = make([]RateQuoteAccessorialItem, 1, 4)
+	q.Request.Accessorials[0].Code = AccessorialLiftgate
+
+	original := q
+	original.Request.Details = append([]RateQuoteDetailItem(nil), q.Request.Details...)
+	original.Request.Accessorials = append([]RateQuoteAccessorialItem(nil), q.Request.Accessorials...)
+
+	xmlString, err := c.BuildRateQuoteXML(&q)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(q, original) {
+		t.Fatalf("request was changed\ngot:      %+v\nexpected: %+v", q, original)
+	}
+	if extra := q.Request.Accessorials[:2]; extra[1].Code != "" {
+		t.Fatalf("expected the caller's array to be untouched, got %v", extra)
+	}
+
+	//the copy that was built has everything filled in
+	for _, s := range []string{"<Customer>12345</Customer>", "<Weight>1102</Weight>", "<Code>APPT</Code>", "<PalletCount>2</PalletCount>"} {
+		if !strings.Contains(xmlString, s) {
+			t.Errorf("expected %s in %s", s, xmlString)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package ward
+
+import "time"
+
+//HolidayCalendar decides which days Ward is closed for a holiday
+//Weekends are always non-business days and don't need to be included.
+type HolidayCalendar interface {
+	IsHoliday(t time.Time) bool
+}
+
+//HolidayList is a HolidayCalendar made from a list of dates
+//Only the year, month, and day of each date are used.
+type HolidayList []time.Time
+
+//IsHoliday implements HolidayCalendar
+func (h HolidayList) IsHoliday(t time.Time) bool {
+	for _, d := range h {
+		if sameDay(d, t) {
+			return true
+		}
+	}
+
+	return false
+}
+
+//USFederalHolidays is a HolidayCalendar of US federal holidays
+//Holidays falling on a Saturday are observed the Friday before and holidays falling on a Sunday are observed
+//the Monday after.
+type USFederalHolidays struct{}
+
+//IsHoliday implements HolidayCalendar
+func (USFederalHolidays) IsHoliday(t time.Time) bool {
+	//check the year before and after too since an observed New Year's Day can fall in the prior year
+	for year := t.Year() - 1; year <= t.Year()+1; year++ {
+		for _, d := range usFederalHolidays(year, t.Location()) {
+			if sameDay(d, t) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+//usFederalHolidays returns the observed dates of the US federal holidays in a year
+func usFederalHolidays(year int, loc *time.Location) []time.Time {
+	fixed := func(m time.Month, d int) time.Time {
+		return observed(time.Date(year, m, d, 0, 0, 0, 0, loc))
+	}
+
+	return []time.Time{
+		fixed(time.January, 1),                                 //new year's day
+		nthWeekday(year, time.January, time.Monday, 3, loc),    //martin luther king jr. day
+		nthWeekday(year, time.February, time.Monday, 3, loc),   //washington's birthday
+		lastWeekday(year, time.May, time.Monday, loc),          //memorial day
+		fixed(time.June, 19),                                   //juneteenth
+		fixed(time.July, 4),                                    //independence day
+		nthWeekday(year, time.September, time.Monday, 1, loc),  //labor day
+		nthWeekday(year, time.October, time.Monday, 2, loc),    //columbus day
+		fixed(time.November, 11),                               //veterans day
+		nthWeekday(year, time.November, time.Thursday, 4, loc), //thanksgiving
+		fixed(time.December, 25),                               //christmas
+	}
+}
+
+//observed moves a holiday on a weekend to the weekday it is observed on
+func observed(t time.Time) time.Time {
+	switch t.Weekday() {
+	case time.Saturday:
+		return t.AddDate(0, 0, -1)
+	case time.Sunday:
+		return t.AddDate(0, 0, 1)
+	}
+
+	return t
+}
+
+//nthWeekday returns the nth occurrence of a weekday in a month, i.e. the third Monday in January
+func nthWeekday(year int, month time.Month, day time.Weekday, n int, loc *time.Location) time.Time {
+	t := time.Date(year, month, 1, 0, 0, 0, 0, loc)
+	offset := (int(day) - int(t.Weekday()) + 7) % 7
+	return t.AddDate(0, 0, offset+7*(n-1))
+}
+
+//lastWeekday returns the last occurrence of a weekday in a month, i.e. the last Monday in May
+func lastWeekday(year int, month time.Month, day time.Weekday, loc *time.Location) time.Time {
+	t := time.Date(year, month+1, 0, 0, 0, 0, 0, loc)
+	offset := (int(t.Weekday()) - int(day) + 7) % 7
+	return t.AddDate(0, 0, -offset)
+}
+
+//sameDay checks if two times are on the same calendar day
+func sameDay(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd
+}
+
+//SetHolidayCalendar sets the HolidayCalendar used for business day math
+//This defaults to US federal holidays.  Pass nil to only skip weekends.
+func SetHolidayCalendar(h HolidayCalendar) {
+	defaultClient.SetHolidayCalendar(h)
+	return
+}
+
+//SetHolidayCalendar sets the HolidayCalendar used for business day math, see SetHolidayCalendar
+func (c *Client) SetHolidayCalendar(h HolidayCalendar) {
+	if h == nil {
+		h = HolidayList{}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.holidays = h
+	return
+}
+
+//IsBusinessDay checks if Ward operates on a day, meaning it is not a weekend or holiday
+//The default client's HolidayCalendar is used, see SetHolidayCalendar.
+func IsBusinessDay(t time.Time) bool {
+	return defaultClient.IsBusinessDay(t)
+}
+
+//IsBusinessDay checks if Ward operates on a day using the client's HolidayCalendar, see IsBusinessDay
+func (c *Client) IsBusinessDay(t time.Time) bool {
+	if t.Weekday() == time.Saturday || t.Weekday() == time.Sunday {
+		return false
+	}
+
+	c.mu.RLock()
+	h := c.holidays
+	c.mu.RUnlock()
+
+	return !h.IsHoliday(t)
+}
+
+//AddBusinessDays moves a date forward by a number of business days, skipping weekends and holidays
+//Adding zero days to a non-business day moves it to the next business day.
+func AddBusinessDays(t time.Time, days int) time.Time {
+	return defaultClient.AddBusinessDays(t, days)
+}
+
+//AddBusinessDays moves a date forward by a number of business days using the client's HolidayCalendar, see
+//AddBusinessDays
+func (c *Client) AddBusinessDays(t time.Time, days int) time.Time {
+	for !c.IsBusinessDay(t) {
+		t = t.AddDate(0, 0, 1)
+	}
+
+	for days > 0 {
+		t = t.AddDate(0, 0, 1)
+		if c.IsBusinessDay(t) {
+			days--
+		}
+	}
+
+	return t
+}
+
+//NextBusinessDay returns the first business day after a date
+func NextBusinessDay(t time.Time) time.Time {
+	return defaultClient.NextBusinessDay(t)
+}
+
+//NextBusinessDay returns the first business day after a date using the client's HolidayCalendar
+func (c *Client) NextBusinessDay(t time.Time) time.Time {
+	t = t.AddDate(0, 0, 1)
+	for !c.IsBusinessDay(t) {
+		t = t.AddDate(0, 0, 1)
+	}
+
+	return t
+}
@@ -0,0 +1,42 @@
+package ward
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHolidayCalendarPerClient(t *testing.T) {
+	monday := time.Date(2024, 3, 4, 0, 0, 0, 0, time.Local)
+	tuesday := monday.AddDate(0, 0, 1)
+	wednesday := monday.AddDate(0, 0, 2)
+
+	c := NewClient(WithClock(frozenClock(frozenNow)))
+	c.SetHolidayCalendar(HolidayList{tuesday})
+
+	if c.IsBusinessDay(tuesday) {
+		t.Fatal("expected the client's holiday to not be a business day")
+	}
+	if !IsBusinessDay(tuesday) {
+		t.Fatal("expected the default client to be unchanged")
+	}
+	if got := c.NextBusinessDay(monday); !got.Equal(wednesday) {
+		t.Fatalf("expected %v, got %v", wednesday, got)
+	}
+	if got := c.AddBusinessDays(monday, 1); !got.Equal(wednesday) {
+		t.Fatalf("expected %v, got %v", wednesday, got)
+	}
+
+	//validating a pickup uses the client's holidays
+	p := testPickupRequest()
+	p.ShipperInfo.PickupDate = frozenPickupDate
+	if !hasIssue(p.issuesFor(c), "ShipperInfo.PickupDate") {
+		t.Fatal("expected the pickup date to be rejected as a holiday")
+	}
+
+	//nil only skips weekends
+	july4 := time.Date(2024, 7, 4, 0, 0, 0, 0, time.Local)
+	c.SetHolidayCalendar(nil)
+	if !c.IsBusinessDay(july4) || IsBusinessDay(july4) {
+		t.Fatal("expected only the client to treat July 4th as a business day")
+	}
+}
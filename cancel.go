@@ -0,0 +1,182 @@
+package ward
+
+import (
+	"context"
+	"encoding/xml"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+//EndpointCancelPickup is the name of the pickup cancellation endpoint, used with SetEndpointConfig
+const EndpointCancelPickup = "cancelpickup"
+
+//ErrNoCancelPickupURL is returned when cancelling a pickup before the url is set
+//Ward's documentation for the pickup service does not include cancelling, so the url of the cancellation
+//service your Ward account has access to must be set with SetCancelPickupURLs.
+var ErrNoCancelPickupURL = errors.New("ward - cancel pickup url is not set")
+
+//reasons a pickup could not be cancelled, use with errors.Is on the error returned by CancelPickup
+var (
+	ErrPickupDispatched = errors.New("ward - pickup has already been dispatched")
+	ErrPickupNotFound   = errors.New("ward - pickup confirmation not found")
+)
+
+//SetCancelPickupURLs sets the test and production urls of Ward's pickup cancellation service
+func SetCancelPickupURLs(test, production string) {
+	defaultClient.SetCancelPickupURLs(test, production)
+	return
+}
+
+//SetCancelPickupURLs sets the test and production urls of Ward's pickup cancellation service, see SetCancelPickupURLs
+func (c *Client) SetCancelPickupURLs(test, production string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.cancelTestURL = test
+	c.cancelProductionURL = production
+	return
+}
+
+//cancelURL returns the pickup cancellation url for the current mode
+func (c *Client) cancelURL() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.production {
+		return c.cancelProductionURL
+	}
+
+	return c.cancelTestURL
+}
+
+//cancelPickupRequest is the body of the xml request to cancel a pickup
+type cancelPickupRequest struct {
+	PickupConfirmation string `xml:"PickupConfirmation"`
+}
+
+//CancelPickupResponse is the data returned when a pickup is cancelled
+type CancelPickupResponse struct {
+	XMLName      xml.Name                   `xml:"Envelope" json:"-"`                                    //dont need "soap12"
+	CreateResult CancelPickupResponseResult `xml:"Body>CreateResponse>CreateResult" json:"createResult"` //dont need "soap12"
+
+	Duration time.Duration `xml:"-" json:"duration"` //how long the call to Ward took, including any retries
+}
+
+//CancelPickupResponseResult is the actual body of the cancellation response
+type CancelPickupResponseResult struct {
+	PickupConfirmation string `xml:"PickupConfirmation" json:"pickupConfirmation"`
+	Cancelled          string `xml:"Cancelled" json:"cancelled"` //Y or N
+	Message            string `xml:"Message" json:"message"`
+
+	Timestamp time.Time `xml:"-" json:"timestamp"` //ward's server time from the response, zero if Ward didn't send one
+}
+
+//CancelError is returned when Ward doesn't cancel a pickup
+//Use errors.Is with ErrPickupDispatched or ErrPickupNotFound to check why, when Ward's message says.
+type CancelError struct {
+	PickupConfirmation string
+	StatusCode         int    //http status code of Ward's response
+	Message            string //Ward's explanation of the failure, if one was given
+	Body               []byte //the raw response
+
+	reason error //ErrPickupDispatched, ErrPickupNotFound, or nil if the reason is unknown
+}
+
+//Error implements the error interface
+func (e *CancelError) Error() string {
+	msg := "ward.CancelPickup - pickup " + e.PickupConfirmation + " not cancelled"
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+
+	return msg + " (status " + strconv.Itoa(e.StatusCode) + ")"
+}
+
+//Is checks if the pickup wasn't cancelled for the reason given by target
+func (e *CancelError) Is(target error) bool {
+	return e.reason != nil && target == e.reason
+}
+
+//cancelReason guesses why a pickup wasn't cancelled from Ward's message
+//Only messages that clearly say the pickup doesn't exist are ErrPickupNotFound.  Other failures, such as an
+//invalid request or an unknown error, aren't given a reason so they aren't mistaken for a missing pickup.
+func cancelReason(msg string) error {
+	msg = strings.ToUpper(msg)
+	switch {
+	case strings.Contains(msg, "DISPATCH"):
+		return ErrPickupDispatched
+	case strings.Contains(msg, "NOT FOUND"), strings.Contains(msg, "DOES NOT EXIST"), strings.Contains(msg, "NO SUCH PICKUP"):
+		return ErrPickupNotFound
+	default:
+		return nil
+	}
+}
+
+//CancelPickup cancels a scheduled pickup using the default client
+func CancelPickup(confirmation string) (responseData CancelPickupResponse, err error) {
+	return defaultClient.CancelPickup(confirmation)
+}
+
+//CancelPickup cancels a scheduled pickup by the confirmation number returned from RequestPickup
+func (c *Client) CancelPickup(confirmation string) (responseData CancelPickupResponse, err error) {
+	//track how long the call takes
+	start := c.now()
+	defer func() {
+		responseData.Duration = c.since(start)
+		c.observe(EndpointCancelPickup, responseData.Duration, err)
+	}()
+
+	endpointURL := c.cancelURL()
+	if endpointURL == "" {
+		err = ErrNoCancelPickupURL
+		return
+	}
+
+	confirmation = strings.TrimSpace(confirmation)
+	if confirmation == "" {
+		err = errors.New("ward.CancelPickup - a pickup confirmation is required")
+		return
+	}
+
+	//convert the cancellation request to an xml
+	xmlString, err := c.buildSOAPBody(cancelPickupRequest{PickupConfirmation: confirmation})
+	if err != nil {
+		err = errors.Wrap(err, "ward.CancelPickup - could not marshal xml")
+		return
+	}
+
+	//make the call to the ward API and read the response
+	body, meta, err := c.doRequest(context.Background(), EndpointCancelPickup, endpointURL, xmlString, true)
+	if err != nil {
+		err = errors.Wrap(err, "ward.CancelPickup - could not make request")
+		return
+	}
+
+	err = unmarshalResponse(body, &responseData)
+	if err != nil {
+		err = errors.Wrap(err, "ward.CancelPickup - could not read response")
+		return
+	}
+
+	//keep Ward's timestamp for auditing
+	responseData.CreateResult.Timestamp = parseServerTimestamp(body)
+
+	//check if Ward confirmed the cancellation
+	if normalizeYN(responseData.CreateResult.Cancelled) != "Y" {
+		msg := strings.TrimSpace(responseData.CreateResult.Message)
+		err = &CancelError{
+			PickupConfirmation: confirmation,
+			StatusCode:         meta.StatusCode,
+			Message:            msg,
+			Body:               body,
+			reason:             cancelReason(msg),
+		}
+		c.logf("%s\n%s", err, body)
+		return
+	}
+
+	return
+}
@@ -0,0 +1,99 @@
+package ward
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+//cancelXML returns a response to a pickup cancellation
+func cancelXML(cancelled, message string) string {
+	return `<?xml version="1.0" encoding="utf-8"?>
+<soap:Envelope xmlns:soap="http://www.w3.org/2003/05/soap-envelope"><soap:Body><CreateResponse><CreateResult>
+<PickupConfirmation>PU123456</PickupConfirmation><Cancelled>` + cancelled + `</Cancelled><Message>` + message + `</Message>
+</CreateResult></CreateResponse></soap:Body></soap:Envelope>`
+}
+
+func TestCancelPickup(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		success bool
+		reason  error
+	}{
+		{"cancelled", cancelXML("Y", ""), true, nil},
+		{"dispatched", cancelXML("N", "PICKUP ALREADY DISPATCHED"), false, ErrPickupDispatched},
+		{"not found", cancelXML("N", "CONFIRMATION NOT FOUND"), false, ErrPickupNotFound},
+		{"unknown reason", cancelXML("N", "TRY AGAIN LATER"), false, nil},
+		{"invalid", cancelXML("N", "INVALID REQUEST"), false, nil},
+	}
+
+	for _, tt := range tests {
+		c, srv := newTestClient(t, respond(http.StatusOK, tt.body))
+		c.SetCancelPickupURLs(srv.URL+"/cancel", srv.URL+"/cancel")
+
+		_, err := c.CancelPickup("PU123456")
+		if tt.success {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %v", tt.name, err)
+			}
+			continue
+		}
+
+		var cancelErr *CancelError
+		if !errors.As(err, &cancelErr) {
+			t.Errorf("%s: expected a *CancelError, got %v", tt.name, err)
+			continue
+		}
+		if cancelErr.PickupConfirmation != "PU123456" || cancelErr.StatusCode != http.StatusOK {
+			t.Errorf("%s: unexpected error fields %+v", tt.name, cancelErr)
+		}
+		if tt.reason != nil && !errors.Is(err, tt.reason) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.reason, err)
+		}
+		if tt.reason == nil && (errors.Is(err, ErrPickupDispatched) || errors.Is(err, ErrPickupNotFound)) {
+			t.Errorf("%s: expected no reason, got %v", tt.name, err)
+		}
+	}
+}
+
+func TestCancelReason(t *testing.T) {
+	tests := []struct {
+		msg    string
+		reason error
+	}{
+		{"", nil},
+		{"PICKUP ALREADY DISPATCHED", ErrPickupDispatched},
+		{"Driver dispatched", ErrPickupDispatched},
+		{"CONFIRMATION NOT FOUND", ErrPickupNotFound},
+		{"pickup not found", ErrPickupNotFound},
+		{"PICKUP DOES NOT EXIST", ErrPickupNotFound},
+		{"NO SUCH PICKUP", ErrPickupNotFound},
+		{"INVALID REQUEST", nil},
+		{"INVALID ACCOUNT", nil},
+		{"UNKNOWN ERROR", nil},
+		{"TRY AGAIN LATER", nil},
+	}
+
+	for _, tt := range tests {
+		if got := cancelReason(tt.msg); got != tt.reason {
+			t.Errorf("%q: expected %v, got %v", tt.msg, tt.reason, got)
+		}
+	}
+}
+
+func TestCancelPickupNoURL(t *testing.T) {
+	c := NewClient()
+	if _, err := c.CancelPickup("PU123456"); !errors.Is(err, ErrNoCancelPickupURL) {
+		t.Fatalf("expected ErrNoCancelPickupURL, got %v", err)
+	}
+}
+
+func TestCancelPickupNoConfirmation(t *testing.T) {
+	c, srv := newTestClient(t, respond(http.StatusOK, cancelXML("Y", "")))
+	c.SetCancelPickupURLs(srv.URL+"/cancel", srv.URL+"/cancel")
+
+	if _, err := c.CancelPickup("  "); err == nil {
+		t.Fatal("expected an error for a blank confirmation")
+	}
+}
@@ -0,0 +1,188 @@
+package ward
+
+import (
+	"encoding/xml"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+//FreightClass is an NMFC freight class, i.e. class 50, 55, 92.5, 100, etc.
+//This is used for both the class sent in a rate quote request and the class Ward sends back in the rate
+//details.  Ward zero pads the class in responses ("0050.0") so this handles parsing that and always writes
+//the same canonical form.  This lets you compare a requested class to a rated class directly.
+type FreightClass float64
+
+//standard freight classes
+const (
+	Class50   FreightClass = 50
+	Class55   FreightClass = 55
+	Class60   FreightClass = 60
+	Class65   FreightClass = 65
+	Class70   FreightClass = 70
+	Class77_5 FreightClass = 77.5
+	Class85   FreightClass = 85
+	Class92_5 FreightClass = 92.5
+	Class100  FreightClass = 100
+	Class110  FreightClass = 110
+	Class125  FreightClass = 125
+	Class150  FreightClass = 150
+	Class175  FreightClass = 175
+	Class200  FreightClass = 200
+	Class250  FreightClass = 250
+	Class300  FreightClass = 300
+	Class400  FreightClass = 400
+	Class500  FreightClass = 500
+)
+
+//standardClasses is every standard freight class in ascending order
+var standardClasses = []FreightClass{
+	Class50, Class55, Class60, Class65, Class70, Class77_5, Class85, Class92_5, Class100,
+	Class110, Class125, Class150, Class175, Class200, Class250, Class300, Class400, Class500,
+}
+
+//ParseFreightClass parses a freight class as Ward formats it, with or without zero padding
+//"0050.0", "050", "50", and "50.00" all parse to class 50.  "092.5" parses to class 92.5.
+func ParseFreightClass(s string) (c FreightClass, err error) {
+	f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		err = errors.Wrap(err, "ward.ParseFreightClass - invalid class")
+		return
+	}
+
+	c = FreightClass(f)
+	return
+}
+
+//String returns the canonical form of the class without padding, i.e. "50" or "92.5"
+//This is the form sent to Ward.
+func (c FreightClass) String() string {
+	return strconv.FormatFloat(float64(c), 'f', -1, 64)
+}
+
+//Float returns the class as a number, useful for half classes like 92.5
+func (c FreightClass) Float() float64 {
+	return float64(c)
+}
+
+//Valid checks if the class is one of the standard freight classes
+func (c FreightClass) Valid() bool {
+	for _, s := range standardClasses {
+		if c == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+//MarshalXML writes the class in its canonical form
+func (c FreightClass) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.EncodeElement(c.String(), start)
+}
+
+//UnmarshalXML reads a class from Ward, removing any zero padding
+//An empty element is left as a zero class.
+func (c *FreightClass) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var s string
+	if err := d.DecodeElement(&s, &start); err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(s) == "" {
+		*c = 0
+		return nil
+	}
+
+	parsed, err := ParseFreightClass(s)
+	if err != nil {
+		return err
+	}
+
+	*c = parsed
+	return nil
+}
+
+//ClassResolver looks up the expected freight class for a commodity, such as a sku from your product catalog
+//Set one with SetClassResolver and CheckClasses will warn when a detail item's class doesn't match.
+type ClassResolver interface {
+	//Class returns the expected class for the commodity, ok is false if the commodity is unknown
+	Class(commodity string) (class FreightClass, ok bool)
+}
+
+//noopClassResolver is the default ClassResolver, it doesn't know any commodities
+type noopClassResolver struct{}
+
+//Class implements ClassResolver
+func (noopClassResolver) Class(commodity string) (class FreightClass, ok bool) {
+	return
+}
+
+//SetClassResolver sets the ClassResolver used to check detail item classes
+//Pass nil to stop checking classes.
+func SetClassResolver(r ClassResolver) {
+	defaultClient.SetClassResolver(r)
+	return
+}
+
+//SetClassResolver sets the ClassResolver used to check detail item classes, see SetClassResolver
+func (c *Client) SetClassResolver(r ClassResolver) {
+	if r == nil {
+		r = noopClassResolver{}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.classResolver = r
+	return
+}
+
+//getClassResolver returns the ClassResolver to check detail item classes with
+func (c *Client) getClassResolver() ClassResolver {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.classResolver
+}
+
+//CheckClasses warns about any detail item whose class doesn't match the class expected for its commodity
+//Only detail items with a Commodity known to the default client's ClassResolver are checked.  Issues are
+//returned as warnings since the class may have been changed on purpose.
+func (r RateQuoteRequest) CheckClasses() (issues []Issue) {
+	return r.checkClasses(defaultClient.getClassResolver())
+}
+
+//checkClasses checks detail item classes against resolver, see CheckClasses
+func (r RateQuoteRequest) checkClasses(resolver ClassResolver) (issues []Issue) {
+	for i, d := range r.Request.Details {
+		if d.Commodity == "" {
+			continue
+		}
+
+		expected, ok := resolver.Class(d.Commodity)
+		if !ok || expected == d.Class {
+			continue
+		}
+
+		issues = append(issues, Issue{
+			Field:   "Request.Details[" + strconv.Itoa(i) + "].Class",
+			Message: "is " + d.Class.String() + " but commodity " + d.Commodity + " is class " + expected.String(),
+			Warning: true,
+		})
+	}
+
+	return
+}
+
+//NormalizedClass returns the class of a rate detail with Ward's zero padding removed
+//Half classes are kept, i.e. "0092.5" is 92.5, so this can be compared to the class sent in the request.
+func (d RateQuoteResponseRateDetails) NormalizedClass() FreightClass {
+	return d.Class
+}
+
+//ClassFloat returns the class of a rate detail as a number, including half classes like 92.5
+func (d RateQuoteResponseRateDetails) ClassFloat() float64 {
+	return d.Class.Float()
+}
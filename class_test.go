@@ -0,0 +1,81 @@
+package ward
+
+import (
+	"encoding/xml"
+	"testing"
+)
+
+func TestNormalizedClass(t *testing.T) {
+	tests := []struct {
+		xml   string
+		class FreightClass
+		float float64
+	}{
+		{"<RateDetails><Class>0050.0</Class></RateDetails>", Class50, 50},
+		{"<RateDetails><Class>0077.5</Class></RateDetails>", Class77_5, 77.5},
+		{"<RateDetails><Class>092.5</Class></RateDetails>", Class92_5, 92.5},
+		{"<RateDetails><Class>100</Class></RateDetails>", Class100, 100},
+	}
+
+	for _, tt := range tests {
+		var d RateQuoteResponseRateDetails
+		if err := xml.Unmarshal([]byte(tt.xml), &d); err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.xml, err)
+		}
+
+		if got := d.NormalizedClass(); got != tt.class {
+			t.Errorf("%s: expected class %s, got %s", tt.xml, tt.class, got)
+		}
+		if got := d.ClassFloat(); got != tt.float {
+			t.Errorf("%s: expected %v, got %v", tt.xml, tt.float, got)
+		}
+	}
+}
+
+func TestNormalizedClassMatchesRequest(t *testing.T) {
+	var d RateQuoteResponseRateDetails
+	if err := xml.Unmarshal([]byte("<RateDetails><Class>0092.5</Class></RateDetails>"), &d); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	//92.5 must not be truncated to 92 or it won't match the requested class
+	requested := RateQuoteDetailItem{Class: Class92_5}
+	if d.NormalizedClass() != requested.Class {
+		t.Fatalf("expected %s to match %s", d.NormalizedClass(), requested.Class)
+	}
+}
+
+//classMap is a ClassResolver made from a map of commodity to class
+type classMap map[string]FreightClass
+
+//Class implements ClassResolver
+func (m classMap) Class(commodity string) (class FreightClass, ok bool) {
+	class, ok = m[commodity]
+	return
+}
+
+func TestClassResolverPerClient(t *testing.T) {
+	q := testRateQuoteRequest()
+	q.Request.Details[0].Commodity = "SKU1"
+
+	c := NewClient()
+	c.SetClassResolver(classMap{"SKU1": Class85})
+
+	issues := q.issuesFor(c)
+	if !hasIssue(issues, "Request.Details[0].Class") {
+		t.Fatalf("expected a class warning, got %+v", issues)
+	}
+	if err := validationError(issues); err != nil {
+		t.Fatalf("expected only warnings, got %v", err)
+	}
+
+	//the default client doesn't know the commodity
+	if issues := q.CheckClasses(); len(issues) != 0 {
+		t.Fatalf("expected no issues, got %+v", issues)
+	}
+
+	c.SetClassResolver(nil)
+	if hasIssue(q.issuesFor(c), "Request.Details[0].Class") {
+		t.Fatal("expected no class warning once the resolver is removed")
+	}
+}
@@ -0,0 +1,289 @@
+package ward
+
+import (
+	"log"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+)
+
+//Client connects to the Ward API
+//Each client has its own configuration so, for example, a test client and a production client can be used at
+//the same time.  Create a client with NewClient.  The package level functions (SetProductionMode, RequestPickup,
+//RateQuote, etc.) use a default client.
+type Client struct {
+	//mu guards the settings below, it is only held briefly so setters don't wait on requests in progress
+	mu sync.RWMutex
+
+	//api urls, defaulting to the urls in Ward's documentation
+	pickupTestURL          string
+	pickupProductionURL    string
+	rateQuoteTestURL       string
+	rateQuoteProductionURL string
+	trackingURL            string //no default, see SetTrackingURL
+	getQuoteURL            string //no default, see SetGetQuoteURL
+	bolTestURL             string //no default, see SetBillOfLadingURLs
+	bolProductionURL       string
+	cancelTestURL          string //no default, see SetCancelPickupURLs
+	cancelProductionURL    string
+
+	//production chooses the production urls, false by default so production is only used when actually needed
+	production bool
+
+	//timeout is how long to wait for a reply from Ward on each attempt
+	timeout time.Duration
+
+	//httpClient is a client set by the user to connect to Ward with, nil uses a default client
+	httpClient *http.Client
+
+	//endpointConfigs holds any configuration set per endpoint, endpoints not in this map use the defaults
+	endpointConfigs   map[string]EndpointConfig
+	endpointConfigsMu sync.RWMutex
+
+	soapVersion SOAPVersion
+
+	//envelopeNamespaces overrides the SOAP version's envelope namespaces, see SetEnvelopeNamespaces
+	envelopeNamespaces EnvelopeNamespaces
+
+	//contentType is sent with raw xml bodies, blank uses the SOAP version's type (legacy for SOAP 1.2)
+	contentType string
+
+	//xmlHeader and trailingNewline frame each request's envelope, see SetXMLFraming
+	xmlHeader       bool
+	trailingNewline bool
+
+	//retries is how many times a failed request is retried, zero disables retrying
+	//retryBackoff is how long to wait before the first retry, this doubles before each following retry
+	//operationTimeout caps the total time spent on a request including all retries, zero means no limit
+	retries          int
+	retryBackoff     time.Duration
+	operationTimeout time.Duration
+
+	//defaultHeaders are added to every request, see SetDefaultHeaders
+	defaultHeaders map[string]string
+
+	//authentication added to every request
+	apiKeyHeader  string
+	apiKey        string
+	requestSigner RequestSigner
+
+	//requestor is used to fill in empty requestor fields on every pickup request
+	requestor Requestor
+
+	//clock provides the current time, see WithClock
+	clock Clock
+
+	//account is your Ward account number filled in on requests that don't have one, see SetAccount
+	account string
+
+	//zipResolver is used to fill in missing cities and states
+	zipResolver ZipResolver
+
+	//autoPalletCount fills in a rate quote's pallet count from the detail pieces when it is zero
+	autoPalletCount bool
+
+	//strictPalletCount makes a rate quote's pallet count that doesn't match the detail pieces a validation error
+	strictPalletCount bool
+
+	//serviceHours are used to validate pickup ready and close times
+	serviceHours ServiceHours
+
+	//holidays is the HolidayCalendar used for business day math
+	holidays HolidayCalendar
+
+	//classResolver is used to check the class of detail items
+	classResolver ClassResolver
+
+	//allowUnverifiedAccessorials lets codes missing from verifiedAccessorials be added to requests
+	allowUnverifiedAccessorials bool
+
+	//pickupCache holds pickup responses by idempotency key so the same pickup isn't scheduled twice
+	pickupCache PickupCache
+
+	//pickupKeys makes pickups with the same idempotency key wait for each other
+	pickupKeys keyLocks
+
+	//quoteCache holds recent rate quotes, nil when caching is off, see SetQuoteCache
+	quoteCache *quoteCache
+
+	//observer is called after each call to Ward
+	observer RequestObserver
+
+	//logger is where failed requests are logged, nil by default so nothing is written unless asked for
+	logger *log.Logger
+
+	//debugLogger is where every call to Ward is logged with contact details masked, see SetDebugLogger
+	debugLogger *slog.Logger
+
+	//debugXML keeps the raw xml of the most recent request and response
+	debugXML        bool
+	lastRequestXML  string
+	lastResponseXML []byte
+	debugMu         sync.Mutex
+}
+
+//defaults for new clients
+//You may need to adjust the timeout based on how slow connecting to Ward is for you.
+//10 seconds is overly long, but sometimes Ward is very slow.
+const (
+	defaultTimeout      = 10 * time.Second
+	defaultRetryBackoff = 1 * time.Second
+)
+
+//NewClient returns a client in test mode with the default settings
+func NewClient(opts ...ClientOption) *Client {
+	c := &Client{
+		pickupTestURL:          pickupRequestTestURL,
+		pickupProductionURL:    pickupRequestProductionURL,
+		rateQuoteTestURL:       rateQuoteTestURL,
+		rateQuoteProductionURL: rateQuoteProductionURL,
+		timeout:                defaultTimeout,
+		endpointConfigs:        map[string]EndpointConfig{},
+		soapVersion:            SOAP12,
+		retryBackoff:           defaultRetryBackoff,
+		zipResolver:            noopZipResolver{},
+		autoPalletCount:        true,
+		serviceHours:           defaultServiceHours,
+		holidays:               USFederalHolidays{},
+		classResolver:          noopClassResolver{},
+		xmlHeader:              true,
+		trailingNewline:        true,
+		pickupCache:            NewMemoryPickupCache(defaultPickupCacheTTL),
+		account:                accountFromEnv(),
+		clock:                  wallClock{},
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+//defaultClient is used by the package level functions
+var defaultClient = NewClient()
+
+//SetProductionMode chooses the production urls for use when yes is true, or the test urls when false
+func (c *Client) SetProductionMode(yes bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.production = yes
+	return
+}
+
+//SetTimeout updates the timeout value to something the user sets, i.e. 30 * time.Second
+//use this to increase the timeout if connecting to Ward is really slow
+func (c *Client) SetTimeout(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.timeout = d
+	return
+}
+
+//SetPickupURLs sets the test and production urls used to request pickups
+//These default to the urls from Ward's documentation.  Use this if Ward moves their service or to point at a
+//mock server.
+func SetPickupURLs(test, production string) {
+	defaultClient.SetPickupURLs(test, production)
+	return
+}
+
+//SetPickupURLs sets the test and production urls used to request pickups, see SetPickupURLs
+func (c *Client) SetPickupURLs(test, production string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.pickupTestURL = test
+	c.pickupProductionURL = production
+	return
+}
+
+//SetRateQuoteURLs sets the test and production urls used to get rate quotes
+//These default to the url from Ward's documentation, Ward uses the same url for both.
+func SetRateQuoteURLs(test, production string) {
+	defaultClient.SetRateQuoteURLs(test, production)
+	return
+}
+
+//SetRateQuoteURLs sets the test and production urls used to get rate quotes, see SetRateQuoteURLs
+func (c *Client) SetRateQuoteURLs(test, production string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.rateQuoteTestURL = test
+	c.rateQuoteProductionURL = production
+	return
+}
+
+//getSOAPVersion returns the SOAP version to build requests with
+func (c *Client) getSOAPVersion() SOAPVersion {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.soapVersion
+}
+
+//getRequestor returns the requestor to fill in on pickup requests
+func (c *Client) getRequestor() Requestor {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.requestor
+}
+
+//getZipResolver returns the ZipResolver to fill in cities and states with
+func (c *Client) getZipResolver() ZipResolver {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.zipResolver
+}
+
+//PickupURL returns the pickup url the default client will send requests to in its current mode
+func PickupURL() string {
+	return defaultClient.PickupURL()
+}
+
+//PickupURL returns the pickup url requests will be sent to in the client's current mode
+//Use this to log or health check which of Ward's environments is being used.  A request's Mode can still
+//choose the other url.
+func (c *Client) PickupURL() string {
+	return c.pickupURL(ModeDefault)
+}
+
+//RateQuoteURL returns the rate quote url the default client will send requests to in its current mode
+func RateQuoteURL() string {
+	return defaultClient.RateQuoteURL()
+}
+
+//RateQuoteURL returns the rate quote url requests will be sent to in the client's current mode, see PickupURL
+func (c *Client) RateQuoteURL() string {
+	return c.rateQuoteURL(ModeDefault)
+}
+
+//pickupURL returns the pickup url for a request's mode
+func (c *Client) pickupURL(m Mode) string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.useProduction(m) {
+		return c.pickupProductionURL
+	}
+
+	return c.pickupTestURL
+}
+
+//rateQuoteURL returns the rate quote url for a request's mode
+func (c *Client) rateQuoteURL(m Mode) string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.useProduction(m) {
+		return c.rateQuoteProductionURL
+	}
+
+	return c.rateQuoteTestURL
+}
@@ -0,0 +1,125 @@
+package ward
+
+import (
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+//slow returns a handler that doesn't respond until d passes or the client gives up
+func slow(d time.Duration, body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		//read the request so the server notices when the client hangs up
+		io.Copy(io.Discard, r.Body)
+
+		select {
+		case <-time.After(d):
+			w.Write([]byte(body))
+		case <-r.Context().Done():
+		}
+	}
+}
+
+func TestSetTimeout(t *testing.T) {
+	c, _ := newTestClient(t, slow(5*time.Second, quoteSuccessXML))
+	c.SetTimeout(50 * time.Millisecond)
+
+	start := time.Now()
+	q := testRateQuoteRequest()
+	_, err := c.RateQuote(&q)
+	if err == nil {
+		t.Fatal("expected a timeout")
+	}
+
+	//the timeout is used as is, not multiplied by a second
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("expected the request to time out after 50ms, took %s", elapsed)
+	}
+}
+
+//pathOf returns a handler that records the path of each request and responds with body
+func pathOf(path *string, body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		*path = r.URL.Path
+		w.Write([]byte(body))
+	}
+}
+
+func TestSetURLs(t *testing.T) {
+	var path string
+	c, srv := newTestClient(t, pathOf(&path, pickupSuccessXML))
+	c.SetPickupURLs(srv.URL+"/pickup-test", srv.URL+"/pickup-production")
+	c.SetRateQuoteURLs(srv.URL+"/quote-test", srv.URL+"/quote-production")
+
+	p := testPickupRequest()
+	if _, err := c.RequestPickup(&p); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if path != "/pickup-test" {
+		t.Fatalf("expected the test pickup url, got %s", path)
+	}
+
+	c.SetProductionMode(true)
+	p = testPickupRequest()
+	if _, err := c.RequestPickup(&p); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if path != "/pickup-production" {
+		t.Fatalf("expected the production pickup url, got %s", path)
+	}
+
+	if got := c.rateQuoteURL(ModeDefault); got != srv.URL+"/quote-production" {
+		t.Fatalf("expected the production rate quote url, got %s", got)
+	}
+	c.SetProductionMode(false)
+	if got := c.rateQuoteURL(ModeDefault); got != srv.URL+"/quote-test" {
+		t.Fatalf("expected the test rate quote url, got %s", got)
+	}
+}
+
+func TestDefaultURLs(t *testing.T) {
+	c := NewClient()
+	if c.pickupURL(ModeDefault) == "" || c.rateQuoteURL(ModeDefault) == "" {
+		t.Fatal("expected the urls from Ward's documentation by default")
+	}
+}
+
+func TestCurrentURLs(t *testing.T) {
+	c := NewClient()
+	c.SetPickupURLs("https://test.example.com/pickup", "https://production.example.com/pickup")
+	c.SetRateQuoteURLs("https://test.example.com/quote", "https://production.example.com/quote")
+
+	if got := c.PickupURL(); got != "https://test.example.com/pickup" {
+		t.Fatalf("expected the test pickup url, got %s", got)
+	}
+	if got := c.RateQuoteURL(); got != "https://test.example.com/quote" {
+		t.Fatalf("expected the test rate quote url, got %s", got)
+	}
+
+	c.SetProductionMode(true)
+	if got := c.PickupURL(); got != "https://production.example.com/pickup" {
+		t.Fatalf("expected the production pickup url, got %s", got)
+	}
+	if got := c.RateQuoteURL(); got != "https://production.example.com/quote" {
+		t.Fatalf("expected the production rate quote url, got %s", got)
+	}
+
+	//the package level functions follow the default client
+	t.Cleanup(func() { SetProductionMode(false) })
+	SetProductionMode(false)
+	if got := PickupURL(); got != pickupRequestTestURL {
+		t.Fatalf("expected the default test pickup url, got %s", got)
+	}
+	if got := RateQuoteURL(); got != rateQuoteTestURL {
+		t.Fatalf("expected the default test rate quote url, got %s", got)
+	}
+
+	SetProductionMode(true)
+	if got := PickupURL(); got != pickupRequestProductionURL {
+		t.Fatalf("expected the default production pickup url, got %s", got)
+	}
+	if got := RateQuoteURL(); got != rateQuoteProductionURL {
+		t.Fatalf("expected the default production rate quote url, got %s", got)
+	}
+}
@@ -0,0 +1,76 @@
+package ward
+
+import "time"
+
+//Clock provides the current time
+//Everything in this package that depends on the current time (pickup dates, business days, quote expiration,
+//cached quotes, and call durations) gets it from a client's clock.  This lets tests freeze time to check date
+//logic deterministically.
+type Clock interface {
+	Now() time.Time
+}
+
+//wallClock is the default Clock using the system time
+type wallClock struct{}
+
+//Now implements Clock
+func (wallClock) Now() time.Time {
+	return time.Now()
+}
+
+//ClientOption configures a Client when it is created, see NewClient
+type ClientOption func(*Client)
+
+//WithClock sets the Clock a client uses for all time dependent logic
+//Each client has its own clock so tests using different clocks can run in parallel.  A nil clock uses the
+//system time.
+func WithClock(clk Clock) ClientOption {
+	return func(c *Client) {
+		if clk == nil {
+			clk = wallClock{}
+		}
+
+		c.clock = clk
+	}
+}
+
+//SetClock sets the Clock the default client uses for all time dependent logic
+//This is also the clock used by methods that don't have a client, such as Validate and Expired.  Pass nil to
+//go back to using the system time.  Prefer NewClient with WithClock in tests.
+func SetClock(clk Clock) {
+	defaultClient.SetClock(clk)
+	return
+}
+
+//SetClock sets the Clock used for all time dependent logic, see SetClock
+func (c *Client) SetClock(clk Clock) {
+	if clk == nil {
+		clk = wallClock{}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.clock = clk
+	return
+}
+
+//now returns the current time from the client's Clock
+func (c *Client) now() time.Time {
+	c.mu.RLock()
+	clk := c.clock
+	c.mu.RUnlock()
+
+	return clk.Now()
+}
+
+//since returns the time elapsed since start according to the client's Clock
+func (c *Client) since(start time.Time) time.Duration {
+	return c.now().Sub(start)
+}
+
+//now returns the current time from the default client's Clock
+//This is for methods that don't have a client, methods on a Client use c.now.
+func now() time.Time {
+	return defaultClient.now()
+}
@@ -0,0 +1,111 @@
+package ward
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+//frozenClock is a Clock that always returns the same time
+type frozenClock time.Time
+
+//Now implements Clock
+func (f frozenClock) Now() time.Time {
+	return time.Time(f)
+}
+
+func TestWithClock(t *testing.T) {
+	//tuesday march 5th 2024
+	pickupDate := "03052024"
+
+	tests := []struct {
+		name    string
+		now     time.Time
+		invalid bool
+	}{
+		{"day before", time.Date(2024, 3, 4, 10, 0, 0, 0, time.Local), false},
+		{"day after", time.Date(2024, 3, 6, 10, 0, 0, 0, time.Local), true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := NewClient(WithClock(frozenClock(tt.now)))
+			p := testPickupRequest()
+			p.ShipperInfo.PickupDate = pickupDate
+
+			_, err := c.BuildPickupXML(&p)
+			if tt.invalid {
+				if err == nil || !strings.Contains(err.Error(), "is in the past") {
+					t.Fatalf("expected pickup date in the past error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestWithClockNil(t *testing.T) {
+	c := NewClient(WithClock(nil))
+	if _, ok := c.clock.(wallClock); !ok {
+		t.Fatalf("expected the wall clock, got %T", c.clock)
+	}
+}
+
+func TestClientSetClock(t *testing.T) {
+	frozen := time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC)
+
+	c := NewClient()
+	c.SetClock(frozenClock(frozen))
+	if got := c.now(); !got.Equal(frozen) {
+		t.Fatalf("expected %v, got %v", frozen, got)
+	}
+
+	//other clients keep the system time
+	if got := NewClient().now(); got.Equal(frozen) {
+		t.Fatal("expected a new client to use the system time")
+	}
+
+	c.SetClock(nil)
+	if got := c.now(); got.Equal(frozen) {
+		t.Fatal("expected the system time after setting a nil clock")
+	}
+}
+
+func TestQuoteCacheUsesClientClock(t *testing.T) {
+	hits := 0
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.Write([]byte(quoteSuccessXML))
+	})
+
+	current := time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC)
+	c.SetClock(frozenClock(current))
+	c.SetQuoteCache(time.Minute)
+
+	for i := 0; i < 2; i++ {
+		q := testRateQuoteRequest()
+		if _, err := c.RateQuote(&q); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if hits != 1 {
+		t.Fatalf("expected the second quote to be cached, got %d calls", hits)
+	}
+
+	//the cached quote expires once the client's clock passes the ttl
+	c.SetClock(frozenClock(current.Add(2 * time.Minute)))
+	q := testRateQuoteRequest()
+	if _, err := c.RateQuote(&q); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if hits != 2 {
+		t.Fatalf("expected the cached quote to expire, got %d calls", hits)
+	}
+}
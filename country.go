@@ -0,0 +1,143 @@
+package ward
+
+import "strings"
+
+//countries Ward ships to, a blank country is the US
+const (
+	CountryUS = "US"
+	CountryCA = "CA"
+)
+
+//canadianProvinces are the two letter codes for Canadian provinces and territories
+var canadianProvinces = map[string]bool{
+	"AB": true, "BC": true, "MB": true, "NB": true, "NL": true, "NS": true, "NT": true,
+	"NU": true, "ON": true, "PE": true, "QC": true, "SK": true, "YT": true,
+}
+
+//normalizeCountry returns the uppercase country code, with a blank country as the US
+func normalizeCountry(country string) string {
+	country = strings.ToUpper(strings.TrimSpace(country))
+	if country == "" {
+		return CountryUS
+	}
+
+	return country
+}
+
+//validCountry checks if a country is one Ward ships to
+func validCountry(country string) bool {
+	switch normalizeCountry(country) {
+	case CountryUS, CountryCA:
+		return true
+	default:
+		return false
+	}
+}
+
+//isRegionCode checks if a state or province is valid for a country
+func isRegionCode(country, region string) bool {
+	if normalizeCountry(country) == CountryCA {
+		return canadianProvinces[strings.ToUpper(region)]
+	}
+
+	return isStateCode(region)
+}
+
+//isPostalCode checks if a zip or postal code is formatted correctly for a country
+//US zip codes are five digits with an optional four digit extension.  Canadian postal codes are A1A 1A1, the
+//space is optional.
+func isPostalCode(country, code string) bool {
+	code = strings.ToUpper(strings.TrimSpace(code))
+
+	if normalizeCountry(country) == CountryCA {
+		code = strings.Replace(code, " ", "", 1)
+		if len(code) != 6 {
+			return false
+		}
+
+		for i, r := range code {
+			isLetter := r >= 'A' && r <= 'Z'
+			isDigit := r >= '0' && r <= '9'
+			if (i%2 == 0 && !isLetter) || (i%2 == 1 && !isDigit) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	if len(code) == 10 && code[5] == '-' {
+		code = code[:5] + code[6:]
+	}
+	if len(code) != 5 && len(code) != 9 {
+		return false
+	}
+
+	for _, r := range code {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+//addressIssues checks the country, state, and zip of an address
+//Blank states and zips are not checked, required fields are checked separately.
+func addressIssues(prefix, country, state, zip string) (issues []Issue) {
+	if !validCountry(country) {
+		issues = append(issues, Issue{Field: prefix + "Country", Message: "must be US or CA"})
+		return
+	}
+
+	if state != "" && !isRegionCode(country, state) {
+		msg := "must be a two letter state code"
+		if normalizeCountry(country) == CountryCA {
+			msg = "must be a two letter province code"
+		}
+		issues = append(issues, Issue{Field: prefix + "State", Message: msg})
+	}
+
+	if strings.TrimSpace(zip) != "" && !isPostalCode(country, zip) {
+		msg := "must be a five or nine digit zip code"
+		if normalizeCountry(country) == CountryCA {
+			msg = "must be a postal code formatted as A1A 1A1"
+		}
+		issues = append(issues, Issue{Field: prefix + "Zipcode", Message: msg})
+	}
+
+	return
+}
+
+//CheckConsignee checks the consignee's address the same way the shipper's is checked
+//The consignee is optional for a pickup, but once any of the address is given the city, state, and zip are
+//required so Ward can route the freight.
+func (s PickupRequestShipment) CheckConsignee() (issues []Issue) {
+	address := []struct {
+		field string
+		value string
+	}{
+		{"Shipment.ConsigneeAddress1", s.ConsigneeAddress1},
+		{"Shipment.ConsigneeCity", s.ConsigneeCity},
+		{"Shipment.ConsigneeState", s.ConsigneeState},
+		{"Shipment.ConsigneeZipcode", s.ConsigneeZipcode},
+	}
+
+	given := false
+	for _, a := range address {
+		if strings.TrimSpace(a.value) != "" {
+			given = true
+			break
+		}
+	}
+	if given {
+		for _, a := range address[1:] {
+			if strings.TrimSpace(a.value) == "" {
+				issues = append(issues, Issue{Field: a.field, Message: "is required when a consignee address is given"})
+			}
+		}
+	}
+
+	issues = append(issues, addressIssues("Shipment.Consignee", s.ConsigneeCountry, s.ConsigneeState, s.ConsigneeZipcode)...)
+	return
+}
@@ -0,0 +1,116 @@
+package ward
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIsPostalCode(t *testing.T) {
+	tests := []struct {
+		country string
+		code    string
+		valid   bool
+	}{
+		{"", "15222", true},
+		{CountryUS, "15222-1234", true},
+		{CountryUS, "1522", false},
+		{CountryUS, "M5V 2T6", false},
+		{CountryCA, "M5V 2T6", true},
+		{CountryCA, "m5v2t6", true},
+		{CountryCA, "M5V 2T", false},
+		{CountryCA, "15222", false},
+		{CountryCA, "5MV 2T6", false},
+	}
+
+	for _, tt := range tests {
+		if got := isPostalCode(tt.country, tt.code); got != tt.valid {
+			t.Errorf("isPostalCode(%q, %q) = %t, expected %t", tt.country, tt.code, got, tt.valid)
+		}
+	}
+}
+
+func TestAddressIssues(t *testing.T) {
+	tests := []struct {
+		name    string
+		country string
+		state   string
+		zip     string
+		fields  []string
+	}{
+		{"us", "", "PA", "15222", nil},
+		{"canada", "ca", "ON", "M5V 2T6", nil},
+		{"unknown country", "MX", "JA", "44100", []string{"Request.OriginCountry"}},
+		{"state for canada", CountryCA, "PA", "M5V 2T6", []string{"Request.OriginState"}},
+		{"us zip for canada", CountryCA, "ON", "15222", []string{"Request.OriginZipcode"}},
+	}
+
+	for _, tt := range tests {
+		issues := addressIssues("Request.Origin", tt.country, tt.state, tt.zip)
+		if len(issues) != len(tt.fields) {
+			t.Errorf("%s: expected issues for %v, got %v", tt.name, tt.fields, issues)
+			continue
+		}
+		for i, f := range tt.fields {
+			if issues[i].Field != f {
+				t.Errorf("%s: expected an issue for %s, got %v", tt.name, f, issues[i])
+			}
+		}
+	}
+}
+
+func TestCheckConsignee(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(s *PickupRequestShipment)
+		fields []string
+	}{
+		{"complete", func(s *PickupRequestShipment) {}, nil},
+		{"nine digit zip", func(s *PickupRequestShipment) { s.ConsigneeZipcode = "44101-1234" }, nil},
+		{"no consignee", func(s *PickupRequestShipment) { *s = PickupRequestShipment{} }, nil},
+		{"long state", func(s *PickupRequestShipment) { s.ConsigneeState = "OHIO" }, []string{"Shipment.ConsigneeState"}},
+		{"numeric state", func(s *PickupRequestShipment) { s.ConsigneeState = "O1" }, []string{"Shipment.ConsigneeState"}},
+		{"short zip", func(s *PickupRequestShipment) { s.ConsigneeZipcode = "4410" }, []string{"Shipment.ConsigneeZipcode"}},
+		{"letters in zip", func(s *PickupRequestShipment) { s.ConsigneeZipcode = "44I01" }, []string{"Shipment.ConsigneeZipcode"}},
+		{"bad state and zip", func(s *PickupRequestShipment) {
+			s.ConsigneeState = "O"
+			s.ConsigneeZipcode = "441011"
+		}, []string{"Shipment.ConsigneeState", "Shipment.ConsigneeZipcode"}},
+		{"address without city, state, or zip", func(s *PickupRequestShipment) {
+			s.ConsigneeCity, s.ConsigneeState, s.ConsigneeZipcode = "", "", ""
+		}, []string{"Shipment.ConsigneeCity", "Shipment.ConsigneeState", "Shipment.ConsigneeZipcode"}},
+		{"canadian postal code", func(s *PickupRequestShipment) {
+			s.ConsigneeCountry, s.ConsigneeState, s.ConsigneeZipcode = CountryCA, "ON", "M5V 2T6"
+		}, nil},
+	}
+
+	for _, tt := range tests {
+		s := PickupRequestShipment{
+			ConsigneeAddress1: "200 OAK AVE",
+			ConsigneeCity:     "CLEVELAND",
+			ConsigneeState:    "OH",
+			ConsigneeZipcode:  "44101",
+		}
+		tt.modify(&s)
+
+		var fields []string
+		for _, i := range s.CheckConsignee() {
+			fields = append(fields, i.Field)
+		}
+		if !reflect.DeepEqual(fields, tt.fields) {
+			t.Errorf("%s: expected issues for %v, got %v", tt.name, tt.fields, fields)
+		}
+	}
+}
+
+func TestValidateConsignee(t *testing.T) {
+	p := testPickupRequest()
+	p.Shipment.ConsigneeState = "OHIO"
+	p.Shipment.ConsigneeZipcode = "4410"
+
+	issues := p.issuesFor(NewClient(WithClock(frozenClock(frozenNow))))
+	for _, field := range []string{"Shipment.ConsigneeState", "Shipment.ConsigneeZipcode"} {
+		if !hasIssue(issues, field) {
+			t.Errorf("expected an issue for %s", field)
+		}
+	}
+}
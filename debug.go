@@ -0,0 +1,58 @@
+package ward
+
+//SetDebugXML chooses if the raw xml sent to and received from Ward is kept
+//This is useful for seeing exactly what was sent and received when filing a support ticket with Ward.  Use
+//LastRequestXML and LastResponseXML to get the xml from the most recent request.
+func SetDebugXML(yes bool) {
+	defaultClient.SetDebugXML(yes)
+	return
+}
+
+//SetDebugXML chooses if the raw xml sent to and received from Ward is kept, see SetDebugXML
+func (c *Client) SetDebugXML(yes bool) {
+	c.debugMu.Lock()
+	defer c.debugMu.Unlock()
+
+	c.debugXML = yes
+	return
+}
+
+//LastRequestXML returns the xml sent to Ward in the most recent request when debugging is on
+func LastRequestXML() string {
+	return defaultClient.LastRequestXML()
+}
+
+//LastRequestXML returns the xml sent to Ward in the most recent request when debugging is on
+func (c *Client) LastRequestXML() string {
+	c.debugMu.Lock()
+	defer c.debugMu.Unlock()
+
+	return c.lastRequestXML
+}
+
+//LastResponseXML returns the raw response from Ward for the most recent request when debugging is on
+func LastResponseXML() []byte {
+	return defaultClient.LastResponseXML()
+}
+
+//LastResponseXML returns the raw response from Ward for the most recent request when debugging is on
+func (c *Client) LastResponseXML() []byte {
+	c.debugMu.Lock()
+	defer c.debugMu.Unlock()
+
+	return c.lastResponseXML
+}
+
+//recordXML keeps the xml from a request if debugging is on
+func (c *Client) recordXML(requestXML string, responseXML []byte) {
+	c.debugMu.Lock()
+	defer c.debugMu.Unlock()
+
+	if !c.debugXML {
+		return
+	}
+
+	c.lastRequestXML = requestXML
+	c.lastResponseXML = responseXML
+	return
+}
@@ -0,0 +1,79 @@
+package ward
+
+//cubicInchesPerFoot converts cubic inches to cubic feet
+const cubicInchesPerFoot = 1728
+
+//densityClasses maps density, in lbs per cubic foot, to freight class
+//Freight with a density over min is the class, heaviest first.  This is the 18 tier density scale, freight that
+//isn't over 1 lb per cubic foot is class 500.
+var densityClasses = []struct {
+	min   float64
+	class FreightClass
+}{
+	{50, Class50},
+	{35, Class55},
+	{30, Class60},
+	{22.5, Class65},
+	{15, Class70},
+	{13.5, Class77_5},
+	{12, Class85},
+	{10.5, Class92_5},
+	{9, Class100},
+	{8, Class110},
+	{7, Class125},
+	{6, Class150},
+	{5, Class175},
+	{4, Class200},
+	{3, Class250},
+	{2, Class300},
+	{1, Class400},
+}
+
+//Density returns the density of freight in lbs per cubic foot from its dimensions in inches and weight in lbs
+//Zero is returned if any dimension isn't greater than zero.
+func Density(lengthIn, widthIn, heightIn, weightLb float64) float64 {
+	if lengthIn <= 0 || widthIn <= 0 || heightIn <= 0 {
+		return 0
+	}
+
+	cubicFeet := lengthIn * widthIn * heightIn / cubicInchesPerFoot
+	return weightLb / cubicFeet
+}
+
+//ClassifyByDensity estimates the freight class from a pallet's dimensions in inches and its weight in lbs
+//This is only an estimate from the density based scale, not an official NMFC classification.  Many commodities
+//have a class set by the NMFC regardless of density and Ward will reclass freight that doesn't match, so use
+//your commodity's NMFC item when you have one.  A density on a boundary is the less dense class, i.e. exactly 30
+//lbs per cubic foot is class 65, since a higher class won't underquote.  A uint can't hold a half class, so
+//freight in the 77.5 or 92.5 bands returns the next higher class, 85 or 100, for the same reason.  Use DensityClass to get the half class.  Zero is
+//returned if the dimensions or weight aren't greater than zero.
+func ClassifyByDensity(lengthIn, widthIn, heightIn, weightLb float64) uint {
+	class := DensityClass(lengthIn, widthIn, heightIn, weightLb)
+	if class == FreightClass(uint(class)) {
+		return uint(class)
+	}
+
+	for _, s := range standardClasses {
+		if s > class && s == FreightClass(uint(s)) {
+			return uint(s)
+		}
+	}
+
+	return uint(class)
+}
+
+//DensityClass estimates the freight class the same as ClassifyByDensity, including half classes
+func DensityClass(lengthIn, widthIn, heightIn, weightLb float64) FreightClass {
+	density := Density(lengthIn, widthIn, heightIn, weightLb)
+	if density <= 0 {
+		return 0
+	}
+
+	for _, d := range densityClasses {
+		if density > d.min {
+			return d.class
+		}
+	}
+
+	return Class500
+}
@@ -0,0 +1,71 @@
+package ward
+
+import "testing"
+
+//cubicFoot is the dimensions of one cubic foot in inches
+const cubicFoot = 12
+
+func TestClassifyByDensity(t *testing.T) {
+	tests := []struct {
+		density float64 //lbs per cubic foot
+		class   uint
+		exact   FreightClass
+	}{
+		//one density inside each band
+		{60, 50, Class50},
+		{40, 55, Class55},
+		{32, 60, Class60},
+		{25, 65, Class65},
+		{18, 70, Class70},
+		{14, 85, Class77_5},
+		{12.5, 85, Class85},
+		{11, 100, Class92_5},
+		{10, 100, Class100},
+		{8.5, 110, Class110},
+		{7.5, 125, Class125},
+		{6.5, 150, Class150},
+		{5.5, 175, Class175},
+		{4.5, 200, Class200},
+		{3.5, 250, Class250},
+		{2.5, 300, Class300},
+		{1.5, 400, Class400},
+		{0.5, 500, Class500},
+
+		//a density on a boundary is the less dense class
+		{50, 55, Class55},
+		{30, 65, Class65},
+		{10.5, 100, Class100},
+		{1, 500, Class500},
+	}
+
+	for _, tt := range tests {
+		//one cubic foot so the weight is the density
+		if got := ClassifyByDensity(cubicFoot, cubicFoot, cubicFoot, tt.density); got != tt.class {
+			t.Errorf("ClassifyByDensity at %v lbs/ft³ = %d, expected %d", tt.density, got, tt.class)
+		}
+		if got := DensityClass(cubicFoot, cubicFoot, cubicFoot, tt.density); got != tt.exact {
+			t.Errorf("DensityClass at %v lbs/ft³ = %s, expected %s", tt.density, got, tt.exact)
+		}
+	}
+}
+
+func TestClassifyByDensityPallet(t *testing.T) {
+	//48x40x48 is 53.33 cubic feet, 1600 lbs is 30 lbs/ft³
+	if got := ClassifyByDensity(48, 40, 48, 1600); got != 65 {
+		t.Fatalf("expected class 65, got %d", got)
+	}
+}
+
+func TestClassifyByDensityInvalid(t *testing.T) {
+	tests := [][4]float64{
+		{0, 40, 48, 1000},
+		{48, -1, 48, 1000},
+		{48, 40, 48, 0},
+	}
+
+	for _, tt := range tests {
+		if got := ClassifyByDensity(tt[0], tt[1], tt[2], tt[3]); got != 0 {
+			t.Errorf("ClassifyByDensity(%v) = %d, expected 0", tt, got)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package ward
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+//names of the components of a quote compared by DiffQuotes
+const (
+	ComponentBase                 = "base"
+	ComponentDiscountPercent      = "discount percent"
+	ComponentDiscount             = "discount"
+	ComponentFuelSurchargePercent = "fuel surcharge percent"
+	ComponentFuelSurcharge        = "fuel surcharge"
+	ComponentNet                  = "net"
+)
+
+//ChargeDelta is the difference in one component of a quote between two quotes
+type ChargeDelta struct {
+	Component  string //one of the Component constants, or "accessorial " plus the code
+	Before     float64
+	After      float64
+	Difference float64 //after minus before
+}
+
+//String explains the difference, i.e. "fuel surcharge percent went up from 28 to 31"
+func (c ChargeDelta) String() string {
+	direction := "went up"
+	if c.Difference < 0 {
+		direction = "went down"
+	}
+
+	return fmt.Sprintf("%s %s from %s to %s", c.Component, direction, formatAmount(c.Before), formatAmount(c.After))
+}
+
+//formatAmount formats an amount or percent without trailing zeros
+func formatAmount(f float64) string {
+	return fmt.Sprintf("%g", math.Round(f*100)/100)
+}
+
+//DiffQuotes compares two quotes and returns each component that changed
+//This explains why a quote went up or down, for example because the fuel surcharge percent increased.
+//Components are the base charge (sum of the rate detail amounts), the discount and fuel surcharge percents and
+//amounts, each accessorial by code, and the net charge.  Components that didn't change are not included.
+func DiffQuotes(a, b RateQuoteResponse) (deltas []ChargeDelta) {
+	ra, rb := a.CreateResult, b.CreateResult
+
+	add := func(component string, before, after float64) {
+		before = math.Round(before*100) / 100
+		after = math.Round(after*100) / 100
+		if before == after {
+			return
+		}
+
+		deltas = append(deltas, ChargeDelta{
+			Component:  component,
+			Before:     before,
+			After:      after,
+			Difference: math.Round((after-before)*100) / 100,
+		})
+	}
+
+	add(ComponentBase, baseCharge(ra), baseCharge(rb))
+	add(ComponentDiscountPercent, ra.DiscountPercent, rb.DiscountPercent)
+	add(ComponentDiscount, ra.DiscountAmount, rb.DiscountAmount)
+	add(ComponentFuelSurchargePercent, ra.FuelSurchargePercent, rb.FuelSurchargePercent)
+	add(ComponentFuelSurcharge, ra.FuelSurchargeAmount, rb.FuelSurchargeAmount)
+
+	//accessorials in either quote, in a consistent order
+	accA, accB := ra.AccessorialBreakdown(), rb.AccessorialBreakdown()
+	codes := []string{}
+	for code := range accA {
+		codes = append(codes, code)
+	}
+	for code := range accB {
+		if _, ok := accA[code]; !ok {
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+
+	for _, code := range codes {
+		add("accessorial "+code, accA[code], accB[code])
+	}
+
+	add(ComponentNet, ra.NetCharge, rb.NetCharge)
+	return
+}
+
+//baseCharge is the sum of the rate detail amounts, before discounts, accessorials, and fuel
+func baseCharge(r RateQuoteResponseResult) (total float64) {
+	for _, d := range r.RateDetails {
+		total += d.Amount
+	}
+
+	return
+}
@@ -0,0 +1,58 @@
+package ward
+
+import (
+	"net/mail"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+//ErrInvalidEmail is returned when an email address is malformed
+var ErrInvalidEmail = errors.New("ward - email address is malformed")
+
+//isEmail checks if s is a single bare email address, i.e. name@example.com
+//Display names and angle brackets are not allowed since Ward sends notifications to the field as is.  The
+//domain must have at least one dot so typos like name@example are caught.
+func isEmail(s string) bool {
+	addr, err := mail.ParseAddress(s)
+	if err != nil || addr.Address != s || addr.Name != "" {
+		return false
+	}
+
+	at := strings.LastIndex(s, "@")
+	domain := s[at+1:]
+	return strings.Contains(domain, ".") && !strings.HasPrefix(domain, ".") && !strings.HasSuffix(domain, ".")
+}
+
+//setEmail trims raw and sets field to it, leaving field unchanged if raw isn't valid
+//A blank raw clears the field since every email field is optional.
+func setEmail(field *string, name, raw string) (err error) {
+	raw = strings.TrimSpace(raw)
+	if raw != "" && !isEmail(raw) {
+		err = errors.Wrap(ErrInvalidEmail, "ward - "+name+" "+raw)
+		return
+	}
+
+	*field = raw
+	return
+}
+
+//SetShipperContactEmail sets the shipper's email, returning an error if it is malformed
+func (s *PickupRequestShipperInformation) SetShipperContactEmail(raw string) error {
+	return setEmail(&s.ShipperContactEmail, "ShipperContactEmail", raw)
+}
+
+//SetThirdPartyContactEmail sets the third party's email, returning an error if it is malformed
+func (s *PickupRequestShipperInformation) SetThirdPartyContactEmail(raw string) error {
+	return setEmail(&s.ThirdPartyContactEmail, "ThirdPartyContactEmail", raw)
+}
+
+//SetWardAssuredContactEmail sets the Ward Assured contact's email, returning an error if it is malformed
+func (s *PickupRequestShipperInformation) SetWardAssuredContactEmail(raw string) error {
+	return setEmail(&s.WardAssuredContactEmail, "WardAssuredContactEmail", raw)
+}
+
+//SetRequestorContactEmail sets the requestor's email, returning an error if it is malformed
+func (s *PickupRequestShipperInformation) SetRequestorContactEmail(raw string) error {
+	return setEmail(&s.RequestorContactEmail, "RequestorContactEmail", raw)
+}
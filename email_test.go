@@ -0,0 +1,97 @@
+package ward
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestIsEmail(t *testing.T) {
+	tests := []struct {
+		email string
+		valid bool
+	}{
+		{"name@example.com", true},
+		{"first.last+tag@mail.example.co", true},
+		{"foo@", false},
+		{"@example.com", false},
+		{"name@example", false},
+		{"name@.example.com", false},
+		{"name@example.com.", false},
+		{"name example.com", false},
+		{"Name <name@example.com>", false},
+		{"a@b@example.com", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := isEmail(tt.email); got != tt.valid {
+			t.Errorf("isEmail(%q) = %v, expected %v", tt.email, got, tt.valid)
+		}
+	}
+}
+
+func TestSetContactEmails(t *testing.T) {
+	setters := []struct {
+		name  string
+		set   func(s *PickupRequestShipperInformation, raw string) error
+		field func(s PickupRequestShipperInformation) string
+	}{
+		{"ShipperContactEmail", (*PickupRequestShipperInformation).SetShipperContactEmail, func(s PickupRequestShipperInformation) string { return s.ShipperContactEmail }},
+		{"ThirdPartyContactEmail", (*PickupRequestShipperInformation).SetThirdPartyContactEmail, func(s PickupRequestShipperInformation) string { return s.ThirdPartyContactEmail }},
+		{"WardAssuredContactEmail", (*PickupRequestShipperInformation).SetWardAssuredContactEmail, func(s PickupRequestShipperInformation) string { return s.WardAssuredContactEmail }},
+		{"RequestorContactEmail", (*PickupRequestShipperInformation).SetRequestorContactEmail, func(s PickupRequestShipperInformation) string { return s.RequestorContactEmail }},
+	}
+
+	for _, st := range setters {
+		var s PickupRequestShipperInformation
+
+		//valid addresses are trimmed
+		if err := st.set(&s, " name@example.com "); err != nil {
+			t.Fatalf("%s: unexpected error: %v", st.name, err)
+		}
+		if got := st.field(s); got != "name@example.com" {
+			t.Fatalf("%s: expected name@example.com, got %q", st.name, got)
+		}
+
+		//a malformed address is rejected, naming the field, and the field is left unchanged
+		err := st.set(&s, "foo@")
+		if !errors.Is(err, ErrInvalidEmail) {
+			t.Fatalf("%s: expected ErrInvalidEmail, got %v", st.name, err)
+		}
+		if !strings.Contains(err.Error(), st.name) {
+			t.Fatalf("%s: expected the error to name the field, got %v", st.name, err)
+		}
+		if got := st.field(s); got != "name@example.com" {
+			t.Fatalf("%s: expected the field to be unchanged, got %q", st.name, got)
+		}
+
+		//blank clears the optional field
+		if err := st.set(&s, " "); err != nil {
+			t.Fatalf("%s: unexpected error: %v", st.name, err)
+		}
+		if got := st.field(s); got != "" {
+			t.Fatalf("%s: expected the field to be cleared, got %q", st.name, got)
+		}
+	}
+}
+
+func TestValidateContactEmails(t *testing.T) {
+	p := testPickupRequest()
+	p.ShipperInfo.ShipperContactEmail = ""
+	p.ShipperInfo.RequestorContactEmail = "requestor@example.com"
+	p.ShipperInfo.ThirdPartyContactEmail = "foo@"
+	p.ShipperInfo.WardAssuredContactEmail = "name@example"
+
+	issues := p.issuesFor(NewClient(WithClock(frozenClock(frozenNow))))
+	for _, field := range []string{"ShipperInfo.ThirdPartyContactEmail", "ShipperInfo.WardAssuredContactEmail"} {
+		if !hasIssue(issues, field) {
+			t.Errorf("expected an issue for %s", field)
+		}
+	}
+	for _, field := range []string{"ShipperInfo.ShipperContactEmail", "ShipperInfo.RequestorContactEmail"} {
+		if hasIssue(issues, field) {
+			t.Errorf("expected no issue for %s", field)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package ward
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+//utf8BOM is the byte order mark some servers put at the start of a utf-8 response
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+//stripBOM removes a leading utf-8 byte order mark, which the xml decoder rejects
+func stripBOM(body []byte) []byte {
+	return bytes.TrimPrefix(body, utf8BOM)
+}
+
+//newDecoder returns an xml decoder for a response from Ward
+//encoding/xml only reads utf-8, so latin-1 responses (iso-8859-1 or windows-1252) are converted as they are
+//read.  Windows-1252 is treated as latin-1, the few characters that differ are not used by Ward.
+func newDecoder(body []byte) *xml.Decoder {
+	d := xml.NewDecoder(bytes.NewReader(stripBOM(body)))
+	d.CharsetReader = charsetReader
+	return d
+}
+
+//charsetReader converts the encodings Ward may use to utf-8
+func charsetReader(charset string, input io.Reader) (io.Reader, error) {
+	switch strings.ToLower(charset) {
+	case "utf-8", "utf8", "us-ascii", "ascii":
+		return input, nil
+	case "iso-8859-1", "iso8859-1", "latin1", "latin-1", "windows-1252", "cp1252":
+		return latin1ToUTF8(input)
+	default:
+		return nil, errors.New("ward.charsetReader - unsupported charset " + charset)
+	}
+}
+
+//latin1ToUTF8 converts latin-1 text to utf-8, each latin-1 byte is the unicode code point of the same value
+//responses are already fully read so there is no need to stream this
+func latin1ToUTF8(input io.Reader) (io.Reader, error) {
+	raw, err := ioutil.ReadAll(input)
+	if err != nil {
+		return nil, err
+	}
+
+	var b strings.Builder
+	b.Grow(len(raw))
+	for _, c := range raw {
+		b.WriteRune(rune(c))
+	}
+
+	return strings.NewReader(b.String()), nil
+}
+
+//unmarshalResponse parses a response from Ward into v, see newDecoder
+func unmarshalResponse(body []byte, v interface{}) error {
+	return newDecoder(body).Decode(v)
+}
@@ -0,0 +1,42 @@
+package ward
+
+import "testing"
+
+func TestUnmarshalResponseBOM(t *testing.T) {
+	body := append([]byte{0xEF, 0xBB, 0xBF}, pickupSuccessXML...)
+
+	var res PickupRequestResponse
+	if err := unmarshalResponse(body, &res); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.CreateResult.PickupConfirmation != "PU123456" {
+		t.Fatalf("unexpected confirmation %q", res.CreateResult.PickupConfirmation)
+	}
+}
+
+func TestUnmarshalResponseLatin1(t *testing.T) {
+	for _, charset := range []string{"ISO-8859-1", "windows-1252"} {
+		//0xC9 is É in latin-1
+		body := []byte(`<?xml version="1.0" encoding="` + charset + `"?>
+<soap:Envelope xmlns:soap="http://www.w3.org/2003/05/soap-envelope"><soap:Body><CreateResponse><CreateResult>
+<PickupConfirmation>PU123456</PickupConfirmation><Message>QU` + "\xc9" + `BEC</Message>
+</CreateResult></CreateResponse></soap:Body></soap:Envelope>`)
+
+		var res PickupRequestResponse
+		if err := unmarshalResponse(body, &res); err != nil {
+			t.Fatalf("%s: unexpected error: %v", charset, err)
+		}
+		if res.CreateResult.Message != "QUÉBEC" {
+			t.Fatalf("%s: expected QUÉBEC, got %q", charset, res.CreateResult.Message)
+		}
+	}
+}
+
+func TestUnmarshalResponseUnsupportedCharset(t *testing.T) {
+	body := []byte(`<?xml version="1.0" encoding="shift_jis"?><Envelope></Envelope>`)
+
+	var res PickupRequestResponse
+	if err := unmarshalResponse(body, &res); err == nil {
+		t.Fatal("expected an error for an unsupported charset")
+	}
+}
@@ -0,0 +1,75 @@
+package ward
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+//PickupError is returned when Ward doesn't schedule a pickup
+//This has the details of Ward's response so you can tell why the pickup failed, i.e. a validation error in the
+//request versus a problem on Ward's end.
+type PickupError struct {
+	StatusCode int    //http status code of Ward's response
+	Message    string //Ward's explanation of the failure, if one was given
+	Body       []byte //the raw response
+}
+
+//Error implements the error interface
+func (e *PickupError) Error() string {
+	msg := "ward.RequestPickup - pickup request failed"
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+
+	return msg + " (status " + strconv.Itoa(e.StatusCode) + ")"
+}
+
+//HTTPError is returned when Ward responds with a non-2xx http status code
+//This usually means Ward is down or erroring, not that the request was malformed, and the body is often an
+//html error page instead of xml.
+type HTTPError struct {
+	StatusCode int
+	Body       []byte //the raw response
+}
+
+//maxErrorSnippet is how much of the response body is included in an HTTPError's message
+const maxErrorSnippet = 200
+
+//Error implements the error interface
+func (e *HTTPError) Error() string {
+	msg := "ward - unexpected http status " + strconv.Itoa(e.StatusCode)
+
+	//collapse whitespace so an html page fits on one line
+	snippet := strings.Join(strings.Fields(string(e.Body)), " ")
+	if len(snippet) > maxErrorSnippet {
+		snippet = snippet[:maxErrorSnippet] + "..."
+	}
+	if snippet != "" {
+		msg += ": " + snippet
+	}
+
+	return msg
+}
+
+//Temporary checks if the status code means Ward is briefly unavailable and the request could be retried
+func (e *HTTPError) Temporary() bool {
+	switch e.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+//QuoteError is returned when Ward responds to a rate quote without a rate
+//Ward doesn't explain why so the raw response is included.
+type QuoteError struct {
+	StatusCode int    //http status code of Ward's response
+	Body       []byte //the raw response
+}
+
+//Error implements the error interface
+func (e *QuoteError) Error() string {
+	return "ward.RateQuote - no rate returned (status " + strconv.Itoa(e.StatusCode) + ")"
+}
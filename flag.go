@@ -0,0 +1,133 @@
+package ward
+
+import "strings"
+
+//YN returns the canonical "Y" or "N" Ward expects for a boolean flag
+func YN(b bool) string {
+	if b {
+		return "Y"
+	}
+
+	return "N"
+}
+
+//normalizeYN converts the common ways of saying yes or no into "Y" or "N"
+//Ward rejects anything other than an uppercase "Y" or "N".  Blank and unrecognized values are returned as
+//is instead of this guessing, validation reports the unrecognized ones, see CheckFlags.
+func normalizeYN(s string) string {
+	switch strings.ToUpper(strings.TrimSpace(s)) {
+	case "Y", "YES", "TRUE", "T", "1":
+		return "Y"
+	case "N", "NO", "FALSE", "F", "0":
+		return "N"
+	default:
+		return s
+	}
+}
+
+//flags returns pointers to every Y/N flag on a shipment
+func (s *PickupRequestShipment) flags() []*string {
+	return []*string{
+		&s.Hazardous,
+		&s.Freezable,
+		&s.DeliveryAppntFlag,
+		&s.WardAssured12PM,
+		&s.WardAssured03PM,
+		&s.WardAssuredTimeDefinite,
+		&s.FullValue,
+		&s.NonStandardSize,
+	}
+}
+
+//CheckFlags checks that each Y/N flag on a shipment is blank or a way of saying yes or no
+//Anything else, i.e. "maybe", would be sent to Ward as is and rejected.
+func (s PickupRequestShipment) CheckFlags() (issues []Issue) {
+	flags := []struct {
+		field string
+		value string
+	}{
+		{"Shipment.Hazardous", s.Hazardous},
+		{"Shipment.Freezable", s.Freezable},
+		{"Shipment.DeliveryAppntFlag", s.DeliveryAppntFlag},
+		{"Shipment.WardAssured12PM", s.WardAssured12PM},
+		{"Shipment.WardAssured03PM", s.WardAssured03PM},
+		{"Shipment.WardAssuredTimeDefinite", s.WardAssuredTimeDefinite},
+		{"Shipment.FullValue", s.FullValue},
+		{"Shipment.NonStandardSize", s.NonStandardSize},
+	}
+	for _, f := range flags {
+		if !validYN(f.value) {
+			issues = append(issues, Issue{Field: f.field, Message: "must be Y or N"})
+		}
+	}
+
+	return
+}
+
+//validYN checks if a flag is blank or normalizes to "Y" or "N"
+func validYN(s string) bool {
+	if strings.TrimSpace(s) == "" {
+		return true
+	}
+
+	yn := normalizeYN(s)
+	return yn == "Y" || yn == "N"
+}
+
+//normalizeFlags converts each Y/N flag to an uppercase "Y" or "N"
+func (s *PickupRequestShipment) normalizeFlags() {
+	for _, f := range s.flags() {
+		*f = normalizeYN(*f)
+	}
+
+	return
+}
+
+//SetHazardous sets if the shipment contains hazardous materials
+func (s *PickupRequestShipment) SetHazardous(b bool) {
+	s.Hazardous = YN(b)
+	return
+}
+
+//SetFreezable sets if the shipment needs to be protected from freezing
+func (s *PickupRequestShipment) SetFreezable(b bool) {
+	s.Freezable = YN(b)
+	return
+}
+
+//SetDeliveryAppntFlag sets if the consignee requires a delivery appointment
+//This is overwritten when DeliveryAppointment is set.
+func (s *PickupRequestShipment) SetDeliveryAppntFlag(b bool) {
+	s.DeliveryAppntFlag = YN(b)
+	return
+}
+
+//SetWardAssured12PM sets if the shipment should be delivered by noon with Ward Assured
+func (s *PickupRequestShipment) SetWardAssured12PM(b bool) {
+	s.WardAssured12PM = YN(b)
+	return
+}
+
+//SetWardAssured03PM sets if the shipment should be delivered by 3pm with Ward Assured
+func (s *PickupRequestShipment) SetWardAssured03PM(b bool) {
+	s.WardAssured03PM = YN(b)
+	return
+}
+
+//SetWardAssuredTimeDefinite sets if the shipment should be delivered within a window with Ward Assured
+func (s *PickupRequestShipment) SetWardAssuredTimeDefinite(b bool) {
+	s.WardAssuredTimeDefinite = YN(b)
+	return
+}
+
+//SetFullValue sets if the shipment should have full value coverage
+func (s *PickupRequestShipment) SetFullValue(b bool) {
+	s.FullValue = YN(b)
+	return
+}
+
+//SetNonStandardSize sets if the shipment has freight that is not a standard size
+func (s *PickupRequestShipment) SetNonStandardSize(b bool) {
+	s.NonStandardSize = YN(b)
+	return
+}
@@ -0,0 +1,123 @@
+package ward
+
+import "testing"
+
+func TestYN(t *testing.T) {
+	if YN(true) != "Y" || YN(false) != "N" {
+		t.Fatalf("expected Y and N, got %s and %s", YN(true), YN(false))
+	}
+}
+
+func TestNormalizeYN(t *testing.T) {
+	tests := []struct {
+		in, out string
+	}{
+		{"Y", "Y"},
+		{"y", "Y"},
+		{" yes ", "Y"},
+		{"true", "Y"},
+		{"1", "Y"},
+		{"N", "N"},
+		{"no", "N"},
+		{"False", "N"},
+		{"0", "N"},
+		{"", ""},
+		{"maybe", "maybe"},
+	}
+
+	for _, tt := range tests {
+		if got := normalizeYN(tt.in); got != tt.out {
+			t.Errorf("normalizeYN(%q) = %q, expected %q", tt.in, got, tt.out)
+		}
+	}
+}
+
+func TestFlagSetters(t *testing.T) {
+	var s PickupRequestShipment
+	s.SetHazardous(true)
+	s.SetFreezable(false)
+	s.SetDeliveryAppntFlag(true)
+	s.SetWardAssured12PM(false)
+	s.SetWardAssured03PM(true)
+	s.SetWardAssuredTimeDefinite(false)
+	s.SetFullValue(true)
+	s.SetNonStandardSize(false)
+
+	expected := []string{"Y", "N", "Y", "N", "Y", "N", "Y", "N"}
+	for i, f := range s.flags() {
+		if *f != expected[i] {
+			t.Errorf("flag %d: expected %s, got %s", i, expected[i], *f)
+		}
+	}
+}
+
+func TestNormalizeFlags(t *testing.T) {
+	s := PickupRequestShipment{Hazardous: "yes", Freezable: "n", FullValue: "true"}
+	s.normalizeFlags()
+
+	if s.Hazardous != "Y" || s.Freezable != "N" || s.FullValue != "Y" {
+		t.Fatalf("expected Y, N, Y, got %s, %s, %s", s.Hazardous, s.Freezable, s.FullValue)
+	}
+
+	//blank flags are left for validation to report
+	if s.NonStandardSize != "" {
+		t.Fatalf("expected a blank flag to stay blank, got %q", s.NonStandardSize)
+	}
+}
+
+func TestCheckFlags(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(s *PickupRequestShipment)
+		field  string
+	}{
+		{"blank", func(s *PickupRequestShipment) {}, ""},
+		{"yes and no", func(s *PickupRequestShipment) { s.Hazardous, s.Freezable = "yes", "n" }, ""},
+		{"hazardous", func(s *PickupRequestShipment) { s.Hazardous = "maybe" }, "Shipment.Hazardous"},
+		{"freezable", func(s *PickupRequestShipment) { s.Freezable = "X" }, "Shipment.Freezable"},
+		{"delivery appointment", func(s *PickupRequestShipment) { s.DeliveryAppntFlag = "2" }, "Shipment.DeliveryAppntFlag"},
+		{"non standard size", func(s *PickupRequestShipment) { s.NonStandardSize = "yep" }, "Shipment.NonStandardSize"},
+	}
+
+	for _, tt := range tests {
+		var s PickupRequestShipment
+		tt.modify(&s)
+
+		issues := s.CheckFlags()
+		if tt.field == "" {
+			if len(issues) != 0 {
+				t.Errorf("%s: expected no issues, got %v", tt.name, issues)
+			}
+			continue
+		}
+		if len(issues) != 1 || issues[0].Field != tt.field {
+			t.Errorf("%s: expected one issue for %s, got %v", tt.name, tt.field, issues)
+		}
+	}
+}
+
+func TestValidateReportsUnknownFlag(t *testing.T) {
+	p := testPickupRequest()
+	p.Shipment.Freezable = "maybe"
+
+	if !hasIssue(p.issuesFor(NewClient(WithClock(frozenClock(frozenNow)))), "Shipment.Freezable") {
+		t.Fatal("expected an issue for Shipment.Freezable")
+	}
+
+	b := testBillOfLadingRequest()
+	b.Items[0].Hazardous = "maybe"
+	if !hasIssue(b.issues(), "Items[0].Hazardous") {
+		t.Fatal("expected an issue for Items[0].Hazardous")
+	}
+}
+
+//hasIssue checks if there is an issue for field
+func hasIssue(issues []Issue, field string) bool {
+	for _, i := range issues {
+		if i.Field == field {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,121 @@
+package ward
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+//EndpointGetQuote is the name of the quote lookup endpoint, used with SetEndpointConfig
+const EndpointGetQuote = "getquote"
+
+//errors returned when looking up a quote
+var (
+	//ErrNoGetQuoteURL is returned when looking up a quote before the url is set
+	//Ward's documentation for the rate quote service does not include looking up a quote, so the url of the
+	//service your Ward account has access to must be set with SetGetQuoteURL.
+	ErrNoGetQuoteURL = errors.New("ward - get quote url is not set")
+
+	ErrQuoteExpired  = errors.New("ward - quote has expired")
+	ErrQuoteNotFound = errors.New("ward - quote not found")
+)
+
+//SetGetQuoteURL sets the url of Ward's quote lookup service
+//Looking up a quote doesn't create anything so the same url is used in test and production mode.
+func SetGetQuoteURL(u string) {
+	defaultClient.SetGetQuoteURL(u)
+	return
+}
+
+//SetGetQuoteURL sets the url of Ward's quote lookup service, see SetGetQuoteURL
+func (c *Client) SetGetQuoteURL(u string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.getQuoteURL = u
+	return
+}
+
+//getQuoteRequest is the body of the xml request to look up a quote
+type getQuoteRequest struct {
+	QuoteID string `xml:"QuoteID"`
+}
+
+//getQuoteMessage reads Ward's explanation when a quote isn't returned
+type getQuoteMessage struct {
+	Message string `xml:"Body>CreateResponse>CreateResult>Message"`
+}
+
+//GetQuote looks up a rate quote using the default client
+func GetQuote(quoteID string) (responseData RateQuoteResponse, err error) {
+	return defaultClient.GetQuote(quoteID)
+}
+
+//GetQuote looks up a rate quote previously returned by RateQuote by its QuoteID
+//Use this to book at the quoted price instead of quoting again.  ErrQuoteExpired is returned if Ward says the
+//quote is no longer good and ErrQuoteNotFound if Ward doesn't return it for any other reason.
+func (c *Client) GetQuote(quoteID string) (responseData RateQuoteResponse, err error) {
+	//track how long the call takes
+	start := c.now()
+	defer func() {
+		responseData.Duration = c.since(start)
+		c.observe(EndpointGetQuote, responseData.Duration, err)
+	}()
+
+	c.mu.RLock()
+	endpointURL := c.getQuoteURL
+	c.mu.RUnlock()
+	if endpointURL == "" {
+		err = ErrNoGetQuoteURL
+		return
+	}
+
+	quoteID = strings.TrimSpace(quoteID)
+	if quoteID == "" {
+		err = errors.New("ward.GetQuote - a quote id is required")
+		return
+	}
+
+	//convert the lookup request to an xml
+	xmlString, err := c.buildSOAPBody(getQuoteRequest{QuoteID: quoteID})
+	if err != nil {
+		err = errors.Wrap(err, "ward.GetQuote - could not marshal xml")
+		return
+	}
+
+	//make the call to the ward API and read the response
+	body, _, err := c.doRequest(context.Background(), EndpointGetQuote, endpointURL, xmlString, true)
+	if err != nil {
+		err = errors.Wrap(err, "ward.GetQuote - could not make request")
+		return
+	}
+
+	err = unmarshalResponse(body, &responseData)
+	if err != nil {
+		err = errors.Wrap(err, "ward.GetQuote - could not read response")
+		return
+	}
+
+	//keep Ward's timestamp for auditing
+	responseData.CreateResult.Timestamp = parseServerTimestamp(body)
+
+	//no quote id means Ward didn't return the quote, check if it was because the quote expired
+	if responseData.CreateResult.QuoteID == "" {
+		//the message is only used to explain the error, so a message that can't be read is ignored
+		var m getQuoteMessage
+		_ = unmarshalResponse(body, &m)
+
+		msg := strings.TrimSpace(m.Message)
+		err = ErrQuoteNotFound
+		if strings.Contains(strings.ToUpper(msg), "EXPIRE") {
+			err = ErrQuoteExpired
+		}
+		if msg != "" {
+			err = errors.Wrap(err, msg)
+		}
+		return
+	}
+
+	return
+}
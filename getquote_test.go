@@ -0,0 +1,61 @@
+package ward
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+//quoteMessageXML is a response to a quote lookup with no quote and a message
+func quoteMessageXML(message string) string {
+	return `<?xml version="1.0" encoding="utf-8"?>
+<soap:Envelope xmlns:soap="http://www.w3.org/2003/05/soap-envelope"><soap:Body><CreateResponse><CreateResult>
+<Message>` + message + `</Message>
+</CreateResult></CreateResponse></soap:Body></soap:Envelope>`
+}
+
+func TestGetQuote(t *testing.T) {
+	var req []byte
+	c, srv := newTestClient(t, capture(&req, http.StatusOK, quoteSuccessXML))
+	c.SetGetQuoteURL(srv.URL + "/getquote")
+
+	res, err := c.GetQuote(" Q98765 ")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.CreateResult.QuoteID != "Q98765" || res.CreateResult.NetCharge != 250.75 {
+		t.Fatalf("unexpected response %+v", res.CreateResult)
+	}
+	if !strings.Contains(string(req), "<QuoteID>Q98765</QuoteID>") {
+		t.Fatalf("expected the trimmed quote id to be sent, got %s", req)
+	}
+}
+
+func TestGetQuoteErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected error
+	}{
+		{"expired", quoteMessageXML("QUOTE HAS EXPIRED"), ErrQuoteExpired},
+		{"not found", quoteMessageXML("NO SUCH QUOTE"), ErrQuoteNotFound},
+		{"no message", quoteEmptyXML, ErrQuoteNotFound},
+	}
+
+	for _, tt := range tests {
+		c, srv := newTestClient(t, respond(http.StatusOK, tt.body))
+		c.SetGetQuoteURL(srv.URL + "/getquote")
+
+		_, err := c.GetQuote("Q98765")
+		if !errors.Is(err, tt.expected) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, err)
+		}
+	}
+}
+
+func TestGetQuoteNoURL(t *testing.T) {
+	if _, err := NewClient().GetQuote("Q98765"); !errors.Is(err, ErrNoGetQuoteURL) {
+		t.Fatalf("expected ErrNoGetQuoteURL, got %v", err)
+	}
+}
@@ -0,0 +1,73 @@
+package ward
+
+import "strings"
+
+//HazmatDetail is the hazardous materials information Ward needs to pick up a hazardous shipment
+//This is only sent when the shipment's Hazardous flag is Y.
+type HazmatDetail struct {
+	UNNumber                  string `xml:"UNNumber"`               //UN or NA followed by four digits, i.e. UN1203
+	HazardClass               string `xml:"HazardClass"`            //i.e. 3 or 2.1
+	PackingGroup              string `xml:"PackingGroup,omitempty"` //I, II, or III, not used by every hazard class
+	ProperShippingName        string `xml:"ProperShippingName"`
+	EmergencyContactName      string `xml:"EmergencyContactName"`
+	EmergencyContactTelephone string `xml:"EmergencyContactTelephone"` //xxxxxxxxxx, only numbers
+}
+
+//isUNNumber checks if an identification number is UN or NA followed by four digits
+func isUNNumber(s string) bool {
+	s = strings.ToUpper(s)
+	if len(s) != 6 || (!strings.HasPrefix(s, "UN") && !strings.HasPrefix(s, "NA")) {
+		return false
+	}
+
+	for _, r := range s[2:] {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+//CheckHazmat checks that a hazardous shipment has complete hazmat details
+func (s PickupRequestShipment) CheckHazmat() (issues []Issue) {
+	if normalizeYN(s.Hazardous) != "Y" {
+		return
+	}
+
+	h := s.Hazmat
+	if h == nil {
+		issues = append(issues, Issue{Field: "Shipment.Hazmat", Message: "is required when Hazardous is Y"})
+		return
+	}
+
+	required := []struct {
+		field string
+		value string
+	}{
+		{"Shipment.Hazmat.UNNumber", h.UNNumber},
+		{"Shipment.Hazmat.HazardClass", h.HazardClass},
+		{"Shipment.Hazmat.ProperShippingName", h.ProperShippingName},
+		{"Shipment.Hazmat.EmergencyContactName", h.EmergencyContactName},
+		{"Shipment.Hazmat.EmergencyContactTelephone", h.EmergencyContactTelephone},
+	}
+	for _, r := range required {
+		if strings.TrimSpace(r.value) == "" {
+			issues = append(issues, Issue{Field: r.field, Message: "is required"})
+		}
+	}
+
+	if h.UNNumber != "" && !isUNNumber(h.UNNumber) {
+		issues = append(issues, Issue{Field: "Shipment.Hazmat.UNNumber", Message: "must be UN or NA followed by four digits"})
+	}
+	switch h.PackingGroup {
+	case "", "I", "II", "III":
+	default:
+		issues = append(issues, Issue{Field: "Shipment.Hazmat.PackingGroup", Message: "must be I, II, or III"})
+	}
+	if h.EmergencyContactTelephone != "" && !isTelephone(h.EmergencyContactTelephone) {
+		issues = append(issues, Issue{Field: "Shipment.Hazmat.EmergencyContactTelephone", Message: "must be ten digits, only numbers"})
+	}
+
+	return
+}
@@ -0,0 +1,79 @@
+package ward
+
+import (
+	"encoding/xml"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+//testHazmatDetail returns complete hazmat details
+func testHazmatDetail() *HazmatDetail {
+	return &HazmatDetail{
+		UNNumber:                  "UN1203",
+		HazardClass:               "3",
+		PackingGroup:              "II",
+		ProperShippingName:        "GASOLINE",
+		EmergencyContactName:      "CHEMTREC",
+		EmergencyContactTelephone: "8004249300",
+	}
+}
+
+func TestCheckHazmat(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(s *PickupRequestShipment)
+		fields []string
+	}{
+		{"complete", func(s *PickupRequestShipment) {}, nil},
+		{"not hazardous", func(s *PickupRequestShipment) { s.Hazardous, s.Hazmat = "N", nil }, nil},
+		{"no details", func(s *PickupRequestShipment) { s.Hazmat = nil }, []string{"Shipment.Hazmat"}},
+		{"missing un number", func(s *PickupRequestShipment) { s.Hazmat.UNNumber = "" }, []string{"Shipment.Hazmat.UNNumber"}},
+		{"malformed un number", func(s *PickupRequestShipment) { s.Hazmat.UNNumber = "1203" }, []string{"Shipment.Hazmat.UNNumber"}},
+		{"na number", func(s *PickupRequestShipment) { s.Hazmat.UNNumber = "na1993" }, nil},
+		{"missing class", func(s *PickupRequestShipment) { s.Hazmat.HazardClass = " " }, []string{"Shipment.Hazmat.HazardClass"}},
+		{"missing contact", func(s *PickupRequestShipment) {
+			s.Hazmat.EmergencyContactName = ""
+			s.Hazmat.EmergencyContactTelephone = ""
+		}, []string{"Shipment.Hazmat.EmergencyContactName", "Shipment.Hazmat.EmergencyContactTelephone"}},
+		{"malformed contact telephone", func(s *PickupRequestShipment) { s.Hazmat.EmergencyContactTelephone = "800-424-9300" }, []string{"Shipment.Hazmat.EmergencyContactTelephone"}},
+		{"bad packing group", func(s *PickupRequestShipment) { s.Hazmat.PackingGroup = "IV" }, []string{"Shipment.Hazmat.PackingGroup"}},
+		{"no packing group", func(s *PickupRequestShipment) { s.Hazmat.PackingGroup = "" }, nil},
+	}
+
+	for _, tt := range tests {
+		s := PickupRequestShipment{Hazardous: "Y", Hazmat: testHazmatDetail()}
+		tt.modify(&s)
+
+		var fields []string
+		for _, i := range s.CheckHazmat() {
+			fields = append(fields, i.Field)
+		}
+		if !reflect.DeepEqual(fields, tt.fields) {
+			t.Errorf("%s: expected issues for %v, got %v", tt.name, tt.fields, fields)
+		}
+	}
+}
+
+func TestHazmatOnlySentWhenHazardous(t *testing.T) {
+	p := testPickupRequest()
+	p.Shipment.Hazmat = testHazmatDetail()
+
+	p.Shipment.Hazardous = "N"
+	b, err := xml.Marshal(p)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(string(b), "<HazmatDetail>") {
+		t.Fatalf("expected no hazmat details, got %s", b)
+	}
+
+	p.Shipment.Hazardous = "Y"
+	b, err = xml.Marshal(p)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(string(b), "<UNNumber>UN1203</UNNumber>") {
+		t.Fatalf("expected hazmat details, got %s", b)
+	}
+}
@@ -0,0 +1,60 @@
+package ward
+
+import (
+	"context"
+	"net/http"
+)
+
+//SetDefaultHeaders sets headers added to every request, such as a key or correlation id a gateway requires
+//These are added after the Content-Type and other headers this package sets, so a header given here replaces
+//the package's.  Use ContextWithHeaders to add or replace headers on a single call.  The api key and request
+//signer are applied last.  Pass nil to stop adding headers.
+func SetDefaultHeaders(headers map[string]string) {
+	defaultClient.SetDefaultHeaders(headers)
+	return
+}
+
+//SetDefaultHeaders sets headers added to every request, see SetDefaultHeaders
+func (c *Client) SetDefaultHeaders(headers map[string]string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	//copy so the caller changing their map later doesn't change the headers sent
+	c.defaultHeaders = make(map[string]string, len(headers))
+	for k, v := range headers {
+		c.defaultHeaders[k] = v
+	}
+
+	return
+}
+
+//headersKey is the context key for the headers added with ContextWithHeaders
+type headersKey struct{}
+
+//ContextWithHeaders returns a context that adds headers to a call made with it, such as RequestPickupContext
+//These are added after the default headers so a header given here replaces a default header.
+func ContextWithHeaders(ctx context.Context, headers map[string]string) context.Context {
+	copied := make(map[string]string, len(headers))
+	for k, v := range headers {
+		copied[k] = v
+	}
+
+	return context.WithValue(ctx, headersKey{}, copied)
+}
+
+//addHeaders sets the default headers and any headers from the request's context
+func (c *Client) addHeaders(ctx context.Context, req *http.Request) {
+	c.mu.RLock()
+	for k, v := range c.defaultHeaders {
+		req.Header.Set(k, v)
+	}
+	c.mu.RUnlock()
+
+	if headers, ok := ctx.Value(headersKey{}).(map[string]string); ok {
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+	}
+
+	return
+}
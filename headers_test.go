@@ -0,0 +1,90 @@
+package ward
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+//headersOf returns a handler that keeps the headers of the last request it got and responds with body
+func headersOf(got *http.Header, body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		*got = r.Header.Clone()
+		w.Write([]byte(body))
+	}
+}
+
+func TestDefaultHeaders(t *testing.T) {
+	var got http.Header
+	c := frozenClient(t, headersOf(&got, quoteSuccessXML))
+
+	headers := map[string]string{"X-Api-Gateway": "gw-key", "X-Correlation-Id": "default"}
+	c.SetDefaultHeaders(headers)
+
+	//changing the map later doesn't change the headers sent
+	headers["X-Api-Gateway"] = "changed"
+
+	q := testRateQuoteRequest()
+	if _, err := c.RateQuote(&q); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Get("X-Api-Gateway") != "gw-key" || got.Get("X-Correlation-Id") != "default" {
+		t.Fatalf("expected the default headers, got %v", got)
+	}
+
+	//nil stops adding headers
+	c.SetDefaultHeaders(nil)
+	if _, err := c.RateQuote(&q); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Get("X-Api-Gateway") != "" {
+		t.Fatalf("expected no default headers, got %v", got)
+	}
+}
+
+func TestDefaultHeadersReplacePackageHeaders(t *testing.T) {
+	var got http.Header
+	c := frozenClient(t, headersOf(&got, quoteSuccessXML))
+	c.SetDefaultHeaders(map[string]string{"Content-Type": "application/soap+xml; charset=utf-8"})
+
+	q := testRateQuoteRequest()
+	if _, err := c.RateQuote(&q); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ct := got.Get("Content-Type"); ct != "application/soap+xml; charset=utf-8" {
+		t.Fatalf("expected the default Content-Type to replace the package's, got %q", ct)
+	}
+}
+
+func TestContextWithHeaders(t *testing.T) {
+	var got http.Header
+	c := frozenClient(t, headersOf(&got, pickupSuccessXML))
+	c.SetDefaultHeaders(map[string]string{"X-Api-Gateway": "gw-key", "X-Correlation-Id": "default"})
+
+	headers := map[string]string{"X-Correlation-Id": "order-1", "X-Tenant": "acme"}
+	ctx := ContextWithHeaders(context.Background(), headers)
+	headers["X-Tenant"] = "changed"
+
+	p := testPickupRequest()
+	if _, err := c.RequestPickupContext(ctx, &p); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Get("X-Api-Gateway") != "gw-key" {
+		t.Errorf("expected the default header to still be sent, got %v", got)
+	}
+	if got.Get("X-Correlation-Id") != "order-1" {
+		t.Errorf("expected the context's header to replace the default, got %q", got.Get("X-Correlation-Id"))
+	}
+	if got.Get("X-Tenant") != "acme" {
+		t.Errorf("expected the context's header as it was when the context was made, got %q", got.Get("X-Tenant"))
+	}
+
+	//the override only applies to calls made with that context
+	p = testPickupRequest()
+	if _, err := c.RequestPickup(&p); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Get("X-Correlation-Id") != "default" || got.Get("X-Tenant") != "" {
+		t.Errorf("expected only the default headers, got %v", got)
+	}
+}
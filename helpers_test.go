@@ -0,0 +1,146 @@
+package ward
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+//update rewrites the golden files in testdata instead of comparing against them, go test -run Golden -update
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+//golden compares got to the golden file testdata/name
+func golden(t *testing.T, name string, got []byte) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, got, 0644); err != nil {
+			t.Fatalf("could not update golden file: %v", err)
+		}
+		return
+	}
+
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("could not read golden file: %v", err)
+	}
+	if !bytes.Equal(got, expected) {
+		t.Fatalf("request doesn't match %s\ngot:\n%s\nexpected:\n%s", path, got, expected)
+	}
+}
+
+//frozenPickupDate is the pickup date used with frozenNow so request bodies don't change from day to day
+const frozenPickupDate = "03052024"
+
+//frozenNow is the day before frozenPickupDate, a monday
+var frozenNow = time.Date(2024, 3, 4, 10, 0, 0, 0, time.Local)
+
+//capture returns a handler that saves the body of each request and responds with the status code and body
+func capture(req *[]byte, statusCode int, body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		*req, _ = io.ReadAll(r.Body)
+		w.WriteHeader(statusCode)
+		w.Write([]byte(body))
+	}
+}
+
+//testPickupRequest returns a pickup request that passes validation, picking up on the next business day
+func testPickupRequest() PickupRequest {
+	return PickupRequest{
+		ShipperInfo: PickupRequestShipperInformation{
+			ShipperCode:             "12345",
+			ShipperName:             "ACME WIDGETS",
+			ShipperAddress1:         "100 MAIN ST",
+			ShipperCity:             "PITTSBURGH",
+			ShipperState:            "PA",
+			ShipperZipcode:          "15222",
+			ShipperContactName:      "JANE DOE",
+			ShipperContactTelephone: "4125550100",
+			ShipperReadyTime:        "0900",
+			ShipperCloseTime:        "1600",
+			PickupDate:              FormatPickupDate(NextBusinessDay(time.Now())),
+		},
+		Shipment: PickupRequestShipment{
+			Pieces:            2,
+			PackageCode:       PackagePallet,
+			Weight:            1200,
+			ConsigneeName:     "BETA SUPPLY",
+			ConsigneeAddress1: "200 OAK AVE",
+			ConsigneeCity:     "CLEVELAND",
+			ConsigneeState:    "OH",
+			ConsigneeZipcode:  "44101",
+			Hazardous:         "N",
+			Freezable:         "N",
+		},
+	}
+}
+
+//testRateQuoteRequest returns a rate quote request that passes validation
+func testRateQuoteRequest() RateQuoteRequest {
+	return RateQuoteRequest{
+		Request: RateQuoteRequestInner{
+			Details: []RateQuoteDetailItem{
+				{Weight: 1000, Pieces: 2, Class: Class70},
+			},
+			OriginCity:         "PITTSBURGH",
+			OriginState:        "PA",
+			OriginZipcode:      "15222",
+			DestinationCity:    "CLEVELAND",
+			DestinationState:   "OH",
+			DestinationZipcode: "44101",
+			Customer:           "12345",
+		},
+	}
+}
+
+//pickupSuccessXML is a response to a pickup that was scheduled
+const pickupSuccessXML = `<?xml version="1.0" encoding="utf-8"?>
+<soap:Envelope xmlns:soap="http://www.w3.org/2003/05/soap-envelope"><soap:Body><CreateResponse><CreateResult>
+<PickupConfirmation>PU123456</PickupConfirmation><Message></Message><PickupTerminal>PIT</PickupTerminal>
+<WardTelephone>8005550100</WardTelephone><WardEmail>pit@example.com</WardEmail>
+</CreateResult></CreateResponse></soap:Body></soap:Envelope>`
+
+//pickupEmptyXML is a response to a pickup that wasn't scheduled, with no confirmation number
+const pickupEmptyXML = `<?xml version="1.0" encoding="utf-8"?>
+<soap:Envelope xmlns:soap="http://www.w3.org/2003/05/soap-envelope"><soap:Body><CreateResponse><CreateResult>
+<PickupConfirmation></PickupConfirmation><Message>NO DRIVERS AVAILABLE</Message>
+</CreateResult></CreateResponse></soap:Body></soap:Envelope>`
+
+//quoteSuccessXML is a response to a rate quote with one rate detail
+const quoteSuccessXML = `<?xml version="1.0" encoding="utf-8"?>
+<soap:Envelope xmlns:soap="http://www.w3.org/2003/05/soap-envelope"><soap:Body><CreateResponse><CreateResult>
+<OriginServiceCenter><ID>1</ID><Name>PITTSBURGH</Name><TransitDays>0</TransitDays></OriginServiceCenter>
+<DestinationServiceCenter><ID>2</ID><Name>CLEVELAND</Name><TransitDays>1</TransitDays></DestinationServiceCenter>
+<Customer>12345</Customer><NetCharge>250.75</NetCharge><Tarrif>WARD500</Tarrif>
+<PricingEffectiveDate>01/02/24</PricingEffectiveDate><QuoteID>Q98765</QuoteID>
+<RateDetails><Class>0070.0</Class><Weight>1000</Weight><Amount>300.00</Amount><Rate>30.00</Rate><Pieces>2</Pieces></RateDetails>
+</CreateResult></CreateResponse></soap:Body></soap:Envelope>`
+
+//newTestClient returns a client sending pickups and rate quotes to a test server using handler
+//The server is closed when the test ends.
+func newTestClient(t *testing.T, handler http.HandlerFunc) (*Client, *httptest.Server) {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	c := NewClient()
+	c.SetPickupURLs(srv.URL+"/pickup", srv.URL+"/pickup")
+	c.SetRateQuoteURLs(srv.URL+"/quote", srv.URL+"/quote")
+	return c, srv
+}
+
+//respond returns a handler that always responds with the status code and body
+func respond(statusCode int, body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(statusCode)
+		w.Write([]byte(body))
+	}
+}
@@ -0,0 +1,295 @@
+package ward
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+//ServiceHours is the part of the day Ward's terminals perform pickups
+//Pickup windows outside of these hours may be ignored or rejected by Ward.
+type ServiceHours struct {
+	Open          string        //hhmm, 24 hour
+	Close         string        //hhmm, 24 hour
+	MinimumWindow time.Duration //shortest ready to close window a driver can realistically make
+}
+
+//defaultServiceHours is used when validating pickup ready and close times
+//Ward's documentation doesn't give pickup hours or a minimum window, so these defaults are assumptions based on
+//typical LTL terminal hours.  Use SetServiceHours with the hours your terminal actually uses.
+var defaultServiceHours = ServiceHours{
+	Open:          "0800",
+	Close:         "1800",
+	MinimumWindow: 2 * time.Hour,
+}
+
+//SetServiceHours sets the hours used to validate pickup ready and close times
+func SetServiceHours(h ServiceHours) {
+	defaultClient.SetServiceHours(h)
+	return
+}
+
+//SetServiceHours sets the hours used to validate pickup ready and close times, see SetServiceHours
+func (c *Client) SetServiceHours(h ServiceHours) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.serviceHours = h
+	return
+}
+
+//getServiceHours returns the hours used to validate pickup ready and close times
+func (c *Client) getServiceHours() ServiceHours {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.serviceHours
+}
+
+//parseHHMM parses a 24 hour hhmm time into the number of minutes since midnight
+func parseHHMM(s string) (minutes int, err error) {
+	if len(s) != 4 {
+		err = errors.New("ward.parseHHMM - time must be 4 digits")
+		return
+	}
+
+	//strconv.Atoi would allow a sign, such as +900
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			err = errors.New("ward.parseHHMM - time must only be numbers")
+			return
+		}
+	}
+
+	n, _ := strconv.Atoi(s)
+	hours, mins := n/100, n%100
+	if hours > 23 || mins > 59 {
+		err = errors.New("ward.parseHHMM - time is out of range")
+		return
+	}
+
+	minutes = hours*60 + mins
+	return
+}
+
+//CheckPickupWindow checks that the ready and close times fall within Ward's service hours
+//Ready and close times that are missing are skipped, those are caught by Validate.  A window shorter
+//than the service hours' minimum window is returned as a warning since a driver may not be able to make it.
+//The default client's service hours are used, see SetServiceHours.
+func (s PickupRequestShipperInformation) CheckPickupWindow() (issues []Issue) {
+	return s.checkPickupWindow(defaultClient.getServiceHours())
+}
+
+//checkPickupWindow checks the ready and close times against hours, see CheckPickupWindow
+func (s PickupRequestShipperInformation) checkPickupWindow(hours ServiceHours) (issues []Issue) {
+	if s.ShipperReadyTime == "" || s.ShipperCloseTime == "" {
+		return
+	}
+
+	ready, err := parseHHMM(s.ShipperReadyTime)
+	if err != nil {
+		issues = append(issues, Issue{Field: "ShipperInfo.ShipperReadyTime", Message: "must be hhmm, 24 hour"})
+	}
+	closing, err2 := parseHHMM(s.ShipperCloseTime)
+	if err2 != nil {
+		issues = append(issues, Issue{Field: "ShipperInfo.ShipperCloseTime", Message: "must be hhmm, 24 hour"})
+	}
+	if err != nil || err2 != nil {
+		return
+	}
+
+	if ready >= closing {
+		issues = append(issues, Issue{Field: "ShipperInfo.ShipperCloseTime", Message: "must be after the ready time"})
+		return
+	}
+
+	if open, err := parseHHMM(hours.Open); err == nil && ready < open {
+		issues = append(issues, Issue{Field: "ShipperInfo.ShipperReadyTime", Message: "is before Ward's service hours start at " + hours.Open})
+	}
+	if end, err := parseHHMM(hours.Close); err == nil && closing > end {
+		issues = append(issues, Issue{Field: "ShipperInfo.ShipperCloseTime", Message: "is after Ward's service hours end at " + hours.Close})
+	}
+
+	window := time.Duration(closing-ready) * time.Minute
+	if window < hours.MinimumWindow {
+		issues = append(issues, Issue{
+			Field:   "ShipperInfo.ShipperCloseTime",
+			Message: "leaves a " + window.String() + " window after the " + s.ShipperReadyTime + " ready time, a driver may not make it",
+			Warning: true,
+		})
+	}
+
+	return
+}
+
+//pickupDateLayout is the format of PickupDate, mmddyyyy
+const pickupDateLayout = "01022006"
+
+//pickupDateLayouts are other common formats a pickup date is accepted in and rewritten to mmddyyyy
+var pickupDateLayouts = []string{
+	pickupDateLayout,
+	"1/2/2006",
+	"2006-01-02",
+}
+
+//parsePickupDate parses a pickup date in any of the accepted formats
+func parsePickupDate(s string, loc *time.Location) (t time.Time, err error) {
+	for _, layout := range pickupDateLayouts {
+		t, err = time.ParseInLocation(layout, strings.TrimSpace(s), loc)
+		if err == nil {
+			return
+		}
+	}
+
+	err = errors.New("ward.parsePickupDate - pickup date must be mmddyyyy")
+	return
+}
+
+//normalizePickupDate rewrites the PickupDate as mmddyyyy if it was given in another accepted format
+func (s *PickupRequestShipperInformation) normalizePickupDate() {
+	t, err := parsePickupDate(s.PickupDate, time.Local)
+	if err != nil {
+		return
+	}
+
+	s.PickupDate = t.Format(pickupDateLayout)
+	return
+}
+
+//CheckPickupDate checks that the pickup date and ready time are still in the future
+//A pickup for today with a ready time that has already passed can't be serviced and Ward will roll or drop it,
+//so this suggests the next business day instead.  The current time comes from the Clock set with SetClock.
+func (s PickupRequestShipperInformation) CheckPickupDate() (issues []Issue) {
+	return s.checkPickupDate(defaultClient)
+}
+
+//checkPickupDate checks the pickup date and ready time with c's clock and holidays, see CheckPickupDate
+func (s PickupRequestShipperInformation) checkPickupDate(c *Client) (issues []Issue) {
+	if s.PickupDate == "" {
+		return
+	}
+
+	current := c.now()
+
+	date, err := parsePickupDate(s.PickupDate, current.Location())
+	if err != nil {
+		issues = append(issues, Issue{Field: "ShipperInfo.PickupDate", Message: "must be mmddyyyy"})
+		return
+	}
+
+	suggest := "the next business day is " + c.NextBusinessDay(current).Format(pickupDateLayout)
+	today := time.Date(current.Year(), current.Month(), current.Day(), 0, 0, 0, 0, current.Location())
+
+	switch {
+	case date.Before(today):
+		issues = append(issues, Issue{Field: "ShipperInfo.PickupDate", Message: "is in the past, " + suggest})
+
+	case !c.IsBusinessDay(date):
+		issues = append(issues, Issue{Field: "ShipperInfo.PickupDate", Message: "is not a business day, " + suggest})
+
+	case sameDay(date, today):
+		ready, err := parseHHMM(s.ShipperReadyTime)
+		if err != nil {
+			//invalid ready times are caught by CheckPickupWindow
+			return
+		}
+
+		minutesNow := current.Hour()*60 + current.Minute()
+		if ready < minutesNow {
+			issues = append(issues, Issue{Field: "ShipperInfo.ShipperReadyTime", Message: "has already passed today, " + suggest})
+		}
+	}
+
+	return
+}
+
+//hhmmLayout is the format of the ready and close times, hhmm 24 hour
+const hhmmLayout = "1504"
+
+//FormatPickupDate formats a time as a pickup date, mmddyyyy, in the local timezone
+func FormatPickupDate(t time.Time) string {
+	return FormatPickupDateIn(t, time.Local)
+}
+
+//FormatPickupDateIn formats a time as a pickup date, mmddyyyy, in a timezone
+//Use this when the shipper is in a different timezone than where this code is running.
+func FormatPickupDateIn(t time.Time, loc *time.Location) string {
+	return t.In(loc).Format(pickupDateLayout)
+}
+
+//FormatTimeHHMM formats a time as a ready or close time, hhmm 24 hour, in the local timezone
+func FormatTimeHHMM(t time.Time) string {
+	return FormatTimeHHMMIn(t, time.Local)
+}
+
+//FormatTimeHHMMIn formats a time as a ready or close time, hhmm 24 hour, in a timezone
+func FormatTimeHHMMIn(t time.Time, loc *time.Location) string {
+	return t.In(loc).Format(hhmmLayout)
+}
+
+//SetPickupDate sets the pickup date from a time in the local timezone
+func (s *PickupRequestShipperInformation) SetPickupDate(t time.Time) {
+	s.PickupDate = FormatPickupDate(t)
+	return
+}
+
+//SetReadyTime sets the ready time from a time in the local timezone
+func (s *PickupRequestShipperInformation) SetReadyTime(t time.Time) {
+	s.ShipperReadyTime = FormatTimeHHMM(t)
+	return
+}
+
+//SetCloseTime sets the close time from a time in the local timezone
+func (s *PickupRequestShipperInformation) SetCloseTime(t time.Time) {
+	s.ShipperCloseTime = FormatTimeHHMM(t)
+	return
+}
+
+//ErrInvalidTimeDefiniteWindow is returned when a time definite window doesn't end after it starts
+var ErrInvalidTimeDefiniteWindow = errors.New("ward - time definite window must end after it starts")
+
+//SetTimeDefiniteWindow sets a Ward Assured time definite delivery window from times in the local timezone
+//This sets the WardAssuredTimeDefinite flag and the start and end times.  Only the time of day is used, so the
+//window can't span midnight.  Nothing is changed if the window doesn't end after it starts.
+func (s *PickupRequestShipment) SetTimeDefiniteWindow(start, end time.Time) (err error) {
+	startHHMM, endHHMM := FormatTimeHHMM(start), FormatTimeHHMM(end)
+	if endHHMM <= startHHMM {
+		err = ErrInvalidTimeDefiniteWindow
+		return
+	}
+
+	s.WardAssuredTimeDefinite = YN(true)
+	s.WardAssuredTimeDefiniteStart = startHHMM
+	s.WardAssuredTimeDefiniteEnd = endHHMM
+	return
+}
+
+//CheckTimeDefiniteWindow checks that the Ward Assured time definite flag and window agree
+//A window needs the flag set, the flag needs a window, and the window must end after it starts.
+func (s PickupRequestShipment) CheckTimeDefiniteWindow() (issues []Issue) {
+	flag := normalizeYN(s.WardAssuredTimeDefinite) == "Y"
+	hasWindow := s.WardAssuredTimeDefiniteStart != "" || s.WardAssuredTimeDefiniteEnd != ""
+
+	if !flag {
+		if hasWindow {
+			issues = append(issues, Issue{Field: "Shipment.WardAssuredTimeDefinite", Message: "must be Y when a time definite window is given"})
+		}
+		return
+	}
+
+	start, startErr := parseHHMM(s.WardAssuredTimeDefiniteStart)
+	if startErr != nil {
+		issues = append(issues, Issue{Field: "Shipment.WardAssuredTimeDefiniteStart", Message: "must be a time as hhmm, 24 hour"})
+	}
+	end, endErr := parseHHMM(s.WardAssuredTimeDefiniteEnd)
+	if endErr != nil {
+		issues = append(issues, Issue{Field: "Shipment.WardAssuredTimeDefiniteEnd", Message: "must be a time as hhmm, 24 hour"})
+	}
+	if startErr == nil && endErr == nil && end <= start {
+		issues = append(issues, Issue{Field: "Shipment.WardAssuredTimeDefiniteEnd", Message: "must be after WardAssuredTimeDefiniteStart"})
+	}
+
+	return
+}
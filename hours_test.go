@@ -0,0 +1,157 @@
+package ward
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatPickupDate(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("timezone data not available")
+	}
+	la, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Skip("timezone data not available")
+	}
+
+	//just after midnight in new york is still the day before in los angeles
+	tm := time.Date(2024, 3, 5, 1, 30, 0, 0, ny)
+
+	if got := FormatPickupDateIn(tm, ny); got != "03052024" {
+		t.Errorf("expected 03052024, got %s", got)
+	}
+	if got := FormatPickupDateIn(tm, la); got != "03042024" {
+		t.Errorf("expected 03042024, got %s", got)
+	}
+	if got := FormatTimeHHMMIn(tm, ny); got != "0130" {
+		t.Errorf("expected 0130, got %s", got)
+	}
+	if got := FormatTimeHHMMIn(tm, la); got != "2230" {
+		t.Errorf("expected 2230, got %s", got)
+	}
+}
+
+func TestSetPickupTimes(t *testing.T) {
+	ready := time.Date(2024, 3, 5, 9, 5, 0, 0, time.Local)
+	closing := time.Date(2024, 3, 5, 16, 30, 0, 0, time.Local)
+
+	var s PickupRequestShipperInformation
+	s.SetPickupDate(ready)
+	s.SetReadyTime(ready)
+	s.SetCloseTime(closing)
+
+	if s.PickupDate != "03052024" {
+		t.Errorf("expected pickup date 03052024, got %s", s.PickupDate)
+	}
+	if s.ShipperReadyTime != "0905" {
+		t.Errorf("expected ready time 0905, got %s", s.ShipperReadyTime)
+	}
+	if s.ShipperCloseTime != "1630" {
+		t.Errorf("expected close time 1630, got %s", s.ShipperCloseTime)
+	}
+}
+
+func TestSetTimeDefiniteWindow(t *testing.T) {
+	start := time.Date(2024, 3, 5, 9, 0, 0, 0, time.Local)
+	end := time.Date(2024, 3, 5, 11, 30, 0, 0, time.Local)
+
+	var s PickupRequestShipment
+	if err := s.SetTimeDefiniteWindow(start, end); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.WardAssuredTimeDefinite != "Y" || s.WardAssuredTimeDefiniteStart != "0900" || s.WardAssuredTimeDefiniteEnd != "1130" {
+		t.Fatalf("unexpected window %s %s-%s", s.WardAssuredTimeDefinite, s.WardAssuredTimeDefiniteStart, s.WardAssuredTimeDefiniteEnd)
+	}
+	if issues := s.CheckTimeDefiniteWindow(); len(issues) != 0 {
+		t.Fatalf("expected no issues, got %v", issues)
+	}
+
+	//a backwards window is rejected and nothing is changed
+	var backwards PickupRequestShipment
+	if err := backwards.SetTimeDefiniteWindow(end, start); err != ErrInvalidTimeDefiniteWindow {
+		t.Fatalf("expected ErrInvalidTimeDefiniteWindow, got %v", err)
+	}
+	if backwards.WardAssuredTimeDefinite != "" || backwards.WardAssuredTimeDefiniteStart != "" {
+		t.Fatalf("expected nothing to be set, got %+v", backwards)
+	}
+}
+
+func TestCheckTimeDefiniteWindow(t *testing.T) {
+	tests := []struct {
+		name   string
+		s      PickupRequestShipment
+		fields []string
+	}{
+		{"none", PickupRequestShipment{}, nil},
+		{"window without flag", PickupRequestShipment{WardAssuredTimeDefiniteStart: "0900", WardAssuredTimeDefiniteEnd: "1100"}, []string{"Shipment.WardAssuredTimeDefinite"}},
+		{"flag without window", PickupRequestShipment{WardAssuredTimeDefinite: "Y"}, []string{"Shipment.WardAssuredTimeDefiniteStart", "Shipment.WardAssuredTimeDefiniteEnd"}},
+		{"backwards", PickupRequestShipment{WardAssuredTimeDefinite: "y", WardAssuredTimeDefiniteStart: "1100", WardAssuredTimeDefiniteEnd: "0900"}, []string{"Shipment.WardAssuredTimeDefiniteEnd"}},
+		{"malformed", PickupRequestShipment{WardAssuredTimeDefinite: "Y", WardAssuredTimeDefiniteStart: "9am", WardAssuredTimeDefiniteEnd: "1100"}, []string{"Shipment.WardAssuredTimeDefiniteStart"}},
+	}
+
+	for _, tt := range tests {
+		issues := tt.s.CheckTimeDefiniteWindow()
+		if len(issues) != len(tt.fields) {
+			t.Errorf("%s: expected issues for %v, got %v", tt.name, tt.fields, issues)
+			continue
+		}
+		for i, f := range tt.fields {
+			if issues[i].Field != f {
+				t.Errorf("%s: expected an issue for %s, got %v", tt.name, f, issues[i])
+			}
+		}
+	}
+}
+
+func TestParseHHMM(t *testing.T) {
+	tests := []struct {
+		s       string
+		minutes int
+		valid   bool
+	}{
+		{"0000", 0, true},
+		{"0930", 570, true},
+		{"2359", 1439, true},
+		{"", 0, false},
+		{"930", 0, false},
+		{"09300", 0, false},
+		{"+900", 0, false},
+		{"-000", 0, false},
+		{" 900", 0, false},
+		{"09:3", 0, false},
+		{"2400", 0, false},
+		{"1260", 0, false},
+	}
+
+	for _, tt := range tests {
+		minutes, err := parseHHMM(tt.s)
+		if (err == nil) != tt.valid {
+			t.Errorf("%q: expected valid %t, got error %v", tt.s, tt.valid, err)
+			continue
+		}
+		if minutes != tt.minutes {
+			t.Errorf("%q: expected %d minutes, got %d", tt.s, tt.minutes, minutes)
+		}
+	}
+}
+
+func TestServiceHoursPerClient(t *testing.T) {
+	s := PickupRequestShipperInformation{ShipperReadyTime: "0700", ShipperCloseTime: "0830"}
+
+	//the default hours start at 0800 and need a two hour window
+	if issues := s.CheckPickupWindow(); len(issues) != 2 {
+		t.Fatalf("expected an early ready time and a short window, got %+v", issues)
+	}
+
+	c := NewClient()
+	c.SetServiceHours(ServiceHours{Open: "0600", Close: "2000", MinimumWindow: time.Hour})
+	if issues := s.checkPickupWindow(c.getServiceHours()); len(issues) != 0 {
+		t.Fatalf("expected no issues with the client's hours, got %+v", issues)
+	}
+
+	//the default client is unchanged
+	if issues := s.CheckPickupWindow(); len(issues) != 2 {
+		t.Fatalf("expected the default hours to be kept, got %+v", issues)
+	}
+}
@@ -0,0 +1,148 @@
+package ward
+
+import (
+	"sync"
+	"time"
+)
+
+//PickupCache stores the responses of pickup requests by idempotency key
+//Implement this with a shared store, such as Redis, to prevent duplicate pickups across processes.
+type PickupCache interface {
+	Get(key string) (res PickupRequestResponse, ok bool)
+	Set(key string, res PickupRequestResponse)
+}
+
+//defaultPickupCacheTTL is how long a Client's default MemoryPickupCache keeps a pickup response
+//Retrying an order's pickup is expected within the same day, a new pickup for the same key after that is
+//more likely to be intended.
+const defaultPickupCacheTTL = 24 * time.Hour
+
+//MemoryPickupCache is a PickupCache kept in memory, this is what a Client uses by default
+//Responses are kept for ttl and expired responses are removed whenever a new one is set, so the cache only
+//grows with the pickups requested within ttl.  A Client's default cache keeps responses for 24 hours.
+type MemoryPickupCache struct {
+	ttl   time.Duration
+	clock Clock
+
+	mu      sync.Mutex
+	entries map[string]pickupCacheEntry
+}
+
+//pickupCacheEntry is a cached pickup response and when it stops being used
+type pickupCacheEntry struct {
+	res     PickupRequestResponse
+	expires time.Time
+}
+
+//NewMemoryPickupCache returns an empty in-memory PickupCache that keeps each response for ttl
+func NewMemoryPickupCache(ttl time.Duration) *MemoryPickupCache {
+	return &MemoryPickupCache{
+		ttl:     ttl,
+		clock:   wallClock{},
+		entries: map[string]pickupCacheEntry{},
+	}
+}
+
+//Get implements PickupCache
+func (m *MemoryPickupCache) Get(key string) (res PickupRequestResponse, ok bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.entries[key]
+	if !ok {
+		return
+	}
+	if !m.clock.Now().Before(e.expires) {
+		delete(m.entries, key)
+		ok = false
+		return
+	}
+
+	res = e.res
+	return
+}
+
+//Set implements PickupCache, removing any expired responses so the cache doesn't grow forever
+func (m *MemoryPickupCache) Set(key string, res PickupRequestResponse) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	current := m.clock.Now()
+	for k, e := range m.entries {
+		if !current.Before(e.expires) {
+			delete(m.entries, k)
+		}
+	}
+
+	m.entries[key] = pickupCacheEntry{res: res, expires: current.Add(m.ttl)}
+	return
+}
+
+//keyLocks is a mutex per key, used so only one pickup with an idempotency key is in progress at a time
+//The zero value is ready to use.
+type keyLocks struct {
+	mu    sync.Mutex
+	locks map[string]*keyLock
+}
+
+//keyLock is the mutex for one key and how many callers are using it, so unused keys can be removed
+type keyLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+//lock locks key, blocking while another caller holds it, and returns the function to unlock it
+func (k *keyLocks) lock(key string) (unlock func()) {
+	k.mu.Lock()
+	if k.locks == nil {
+		k.locks = map[string]*keyLock{}
+	}
+	l, ok := k.locks[key]
+	if !ok {
+		l = &keyLock{}
+		k.locks[key] = l
+	}
+	l.refs++
+	k.mu.Unlock()
+
+	l.mu.Lock()
+
+	return func() {
+		l.mu.Unlock()
+
+		k.mu.Lock()
+		l.refs--
+		if l.refs == 0 {
+			delete(k.locks, key)
+		}
+		k.mu.Unlock()
+	}
+}
+
+//SetPickupCache sets where pickup responses are kept by idempotency key
+//When a PickupRequest has an IdempotencyKey that already scheduled a pickup, the cached response is returned
+//instead of sending the request to Ward again.  Requests on the same client with the same key wait for each
+//other, so only one is sent even when they are made at the same time.  This is only done client side, Ward
+//doesn't know about the key, so requests with the same key sent at the same time from different clients or
+//processes can still both be sent.  Pass nil to stop caching.
+func SetPickupCache(cache PickupCache) {
+	defaultClient.SetPickupCache(cache)
+	return
+}
+
+//SetPickupCache sets where pickup responses are kept by idempotency key, see SetPickupCache
+func (c *Client) SetPickupCache(cache PickupCache) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.pickupCache = cache
+	return
+}
+
+//getPickupCache returns the PickupCache, nil if caching is off
+func (c *Client) getPickupCache() PickupCache {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.pickupCache
+}
@@ -0,0 +1,118 @@
+package ward
+
+import (
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+//counting returns a handler that counts every call and responds with body after a short delay
+func counting(calls *int32, body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(calls, 1)
+		time.Sleep(20 * time.Millisecond)
+		w.Write([]byte(body))
+	}
+}
+
+//TestIdempotencyKeyConcurrent sends the same pickup from many goroutines at once, run with -race
+func TestIdempotencyKeyConcurrent(t *testing.T) {
+	var calls int32
+	c, _ := newTestClient(t, counting(&calls, pickupSuccessXML))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			p := testPickupRequest()
+			p.IdempotencyKey = "order-1"
+			res, err := c.RequestPickup(&p)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			if res.CreateResult.PickupConfirmation != "PU123456" {
+				t.Errorf("unexpected confirmation %q", res.CreateResult.PickupConfirmation)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if calls != 1 {
+		t.Fatalf("expected 1 call to Ward, got %d", calls)
+	}
+	if len(c.pickupKeys.locks) != 0 {
+		t.Fatalf("expected the key locks to be removed, got %d", len(c.pickupKeys.locks))
+	}
+}
+
+func TestIdempotencyKeyDifferentKeys(t *testing.T) {
+	var calls int32
+	c, _ := newTestClient(t, counting(&calls, pickupSuccessXML))
+
+	var wg sync.WaitGroup
+	for _, key := range []string{"order-1", "order-2", "order-3"} {
+		wg.Add(1)
+		go func(key string) {
+			defer wg.Done()
+
+			p := testPickupRequest()
+			p.IdempotencyKey = key
+			if _, err := c.RequestPickup(&p); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}(key)
+	}
+	wg.Wait()
+
+	if calls != 3 {
+		t.Fatalf("expected 3 calls to Ward, got %d", calls)
+	}
+}
+
+func TestIdempotencyKeyFailureNotCached(t *testing.T) {
+	var calls int32
+	c, _ := newTestClient(t, counting(&calls, pickupEmptyXML))
+
+	for i := 0; i < 2; i++ {
+		p := testPickupRequest()
+		p.IdempotencyKey = "order-1"
+		if _, err := c.RequestPickup(&p); err == nil {
+			t.Fatal("expected an error")
+		}
+	}
+
+	//a failed pickup can be tried again
+	if calls != 2 {
+		t.Fatalf("expected 2 calls to Ward, got %d", calls)
+	}
+}
+
+func TestMemoryPickupCacheExpires(t *testing.T) {
+	m := NewMemoryPickupCache(time.Hour)
+	m.clock = frozenClock(frozenNow)
+
+	res := PickupRequestResponse{CreateResult: PickupRequestResponseResult{PickupConfirmation: "PU123456"}}
+	m.Set("order-1", res)
+	if got, ok := m.Get("order-1"); !ok || got.CreateResult.PickupConfirmation != "PU123456" {
+		t.Fatalf("expected the cached response, got %+v, %v", got, ok)
+	}
+
+	//an hour later the response has expired and is removed when the next one is set
+	m.clock = frozenClock(frozenNow.Add(time.Hour))
+	if _, ok := m.Get("order-1"); ok {
+		t.Fatal("expected the response to have expired")
+	}
+
+	m.clock = frozenClock(frozenNow)
+	m.Set("order-1", res)
+	m.clock = frozenClock(frozenNow.Add(2 * time.Hour))
+	m.Set("order-2", res)
+	if len(m.entries) != 1 {
+		t.Fatalf("expected the expired response to be removed, got %d entries", len(m.entries))
+	}
+}
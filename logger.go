@@ -0,0 +1,33 @@
+package ward
+
+import "log"
+
+//SetLogger sets a logger to log failed requests to
+//Nothing is logged by default so the package doesn't write anything unless asked to.  Everything logged is also
+//available from the returned errors.  Pass nil to stop logging.
+func SetLogger(l *log.Logger) {
+	defaultClient.SetLogger(l)
+	return
+}
+
+//SetLogger sets a logger to log failed requests to, see SetLogger
+func (c *Client) SetLogger(l *log.Logger) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.logger = l
+	return
+}
+
+//logf logs to the logger if one is set
+func (c *Client) logf(format string, v ...interface{}) {
+	c.mu.RLock()
+	l := c.logger
+	c.mu.RUnlock()
+
+	if l == nil {
+		return
+	}
+
+	l.Printf(format, v...)
+}
@@ -0,0 +1,49 @@
+package ward
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestSetLogger(t *testing.T) {
+	c, _ := newTestClient(t, respond(http.StatusOK, pickupEmptyXML))
+
+	var buf bytes.Buffer
+	c.SetLogger(log.New(&buf, "", 0))
+
+	p := testPickupRequest()
+	if _, err := c.RequestPickup(&p); err == nil {
+		t.Fatal("expected an error for an empty confirmation")
+	}
+	if !strings.Contains(buf.String(), "NO DRIVERS AVAILABLE") {
+		t.Fatalf("expected the failed pickup to be logged, got %q", buf.String())
+	}
+}
+
+func TestNoLoggerByDefault(t *testing.T) {
+	//nothing should be written to the standard logger
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+
+	c, _ := newTestClient(t, respond(http.StatusOK, pickupEmptyXML))
+	p := testPickupRequest()
+	if _, err := c.RequestPickup(&p); err == nil {
+		t.Fatal("expected an error for an empty confirmation")
+	}
+
+	//nil stops logging after a logger was set
+	c.SetLogger(log.New(&buf, "", 0))
+	c.SetLogger(nil)
+	if _, err := c.RequestPickup(&p); err == nil {
+		t.Fatal("expected an error for an empty confirmation")
+	}
+
+	if buf.Len() != 0 {
+		t.Fatalf("expected nothing to be logged, got %q", buf.String())
+	}
+}
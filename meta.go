@@ -0,0 +1,10 @@
+package ward
+
+import "net/http"
+
+//ResponseMeta is information about Ward's http response, returned by the WithMeta variants of each call
+//Use this to see headers Ward or a gateway in front of Ward adds, such as a request id for support tickets.
+type ResponseMeta struct {
+	StatusCode int
+	Header     http.Header
+}
@@ -0,0 +1,64 @@
+package ward
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+//withHeader returns a handler that sets a response header then responds with body
+func withHeader(key, value, body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(key, value)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(body))
+	}
+}
+
+func TestRequestPickupWithMeta(t *testing.T) {
+	c := frozenClient(t, withHeader("X-Request-Id", "req-123", pickupSuccessXML))
+
+	p := testPickupRequest()
+	res, meta, err := c.RequestPickupWithMeta(context.Background(), &p)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.CreateResult.PickupConfirmation != "PU123456" {
+		t.Fatalf("unexpected confirmation %q", res.CreateResult.PickupConfirmation)
+	}
+	if meta.StatusCode != http.StatusOK || meta.Header.Get("X-Request-Id") != "req-123" {
+		t.Fatalf("expected status 200 and the request id header, got %d and %v", meta.StatusCode, meta.Header)
+	}
+
+	//a pickup returned from the cache didn't get a response
+	p = testPickupRequest()
+	p.IdempotencyKey = "order-1"
+	if _, _, err := c.RequestPickupWithMeta(context.Background(), &p); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p = testPickupRequest()
+	p.IdempotencyKey = "order-1"
+	_, meta, err = c.RequestPickupWithMeta(context.Background(), &p)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if meta.StatusCode != 0 || meta.Header != nil {
+		t.Fatalf("expected no meta for a cached pickup, got %+v", meta)
+	}
+}
+
+func TestRateQuoteWithMeta(t *testing.T) {
+	c := frozenClient(t, withHeader("X-Request-Id", "req-456", quoteSuccessXML))
+
+	q := testRateQuoteRequest()
+	res, meta, err := c.RateQuoteWithMeta(context.Background(), &q)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.CreateResult.QuoteID != "Q98765" {
+		t.Fatalf("unexpected quote id %q", res.CreateResult.QuoteID)
+	}
+	if meta.StatusCode != http.StatusOK || meta.Header.Get("X-Request-Id") != "req-456" {
+		t.Fatalf("expected status 200 and the request id header, got %d and %v", meta.StatusCode, meta.Header)
+	}
+}
@@ -0,0 +1,36 @@
+package ward
+
+//Mode chooses between Ward's test and production urls for a single request
+type Mode int
+
+//modes a request can be sent in
+const (
+	ModeDefault    Mode = iota //use the client's mode, see SetProductionMode
+	ModeTest                   //always use the test urls
+	ModeProduction             //always use the production urls
+)
+
+//String returns a human readable name of the mode
+func (m Mode) String() string {
+	switch m {
+	case ModeTest:
+		return "test"
+	case ModeProduction:
+		return "production"
+	default:
+		return "default"
+	}
+}
+
+//useProduction checks if a request in mode m should use the production urls
+//c.mu must be held.
+func (c *Client) useProduction(m Mode) bool {
+	switch m {
+	case ModeTest:
+		return false
+	case ModeProduction:
+		return true
+	default:
+		return c.production
+	}
+}
@@ -0,0 +1,105 @@
+package ward
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+//modeServers returns a client whose test urls go to one server and production urls go to another, and the
+//number of calls each server got
+func modeServers(t *testing.T) (c *Client, testCalls, productionCalls *int32) {
+	t.Helper()
+
+	testCalls, productionCalls = new(int32), new(int32)
+	handler := func(calls *int32) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(calls, 1)
+			if r.URL.Path == "/pickup" {
+				w.Write([]byte(pickupSuccessXML))
+				return
+			}
+			w.Write([]byte(quoteSuccessXML))
+		}
+	}
+
+	testSrv := httptest.NewServer(handler(testCalls))
+	t.Cleanup(testSrv.Close)
+	productionSrv := httptest.NewServer(handler(productionCalls))
+	t.Cleanup(productionSrv.Close)
+
+	c = NewClient(WithClock(frozenClock(frozenNow)))
+	c.SetPickupURLs(testSrv.URL+"/pickup", productionSrv.URL+"/pickup")
+	c.SetRateQuoteURLs(testSrv.URL+"/quote", productionSrv.URL+"/quote")
+	return
+}
+
+func TestRequestMode(t *testing.T) {
+	tests := []struct {
+		name       string
+		production bool
+		mode       Mode
+		toProd     bool
+	}{
+		{"default in test mode", false, ModeDefault, false},
+		{"default in production mode", true, ModeDefault, true},
+		{"test in production mode", true, ModeTest, false},
+		{"production in test mode", false, ModeProduction, true},
+	}
+
+	for _, tt := range tests {
+		c, testCalls, productionCalls := modeServers(t)
+		c.SetProductionMode(tt.production)
+
+		p := testPickupRequest()
+		p.Mode = tt.mode
+		if _, err := c.RequestPickup(&p); err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+		q := testRateQuoteRequest()
+		q.Mode = tt.mode
+		if _, err := c.RateQuote(&q); err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+
+		want := [2]int32{2, 0}
+		if tt.toProd {
+			want = [2]int32{0, 2}
+		}
+		if got := [2]int32{*testCalls, *productionCalls}; got != want {
+			t.Errorf("%s: expected %d test and %d production calls, got %d and %d", tt.name, want[0], want[1], got[0], got[1])
+		}
+	}
+}
+
+func TestRequestModeMixed(t *testing.T) {
+	//a test pickup and a production quote from the same client without changing its mode
+	c, testCalls, productionCalls := modeServers(t)
+
+	p := testPickupRequest()
+	p.Mode = ModeTest
+	if _, err := c.RequestPickup(&p); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	q := testRateQuoteRequest()
+	q.Mode = ModeProduction
+	if _, err := c.RateQuote(&q); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if *testCalls != 1 || *productionCalls != 1 {
+		t.Fatalf("expected 1 test and 1 production call, got %d and %d", *testCalls, *productionCalls)
+	}
+	if c.PickupURL() != c.pickupURL(ModeTest) {
+		t.Fatal("expected the client to still be in test mode")
+	}
+}
+
+func TestModeString(t *testing.T) {
+	for m, s := range map[Mode]string{ModeDefault: "default", ModeTest: "test", ModeProduction: "production"} {
+		if m.String() != s {
+			t.Errorf("expected %s, got %s", s, m.String())
+		}
+	}
+}
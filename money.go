@@ -0,0 +1,202 @@
+package ward
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+//parseCents parses a dollar amount into a whole number of cents
+//A leading dollar sign and correctly placed thousands separators are allowed ("$1,000.00").  Anything that
+//isn't an exact dollar amount is rejected instead of guessed at, including more than two decimal places,
+//misplaced commas, and negative amounts.
+func parseCents(s string) (cents int64, err error) {
+	s = strings.TrimSpace(s)
+	s = strings.TrimPrefix(s, "$")
+	if s == "" {
+		err = errors.New("ward.parseCents - amount is empty")
+		return
+	}
+
+	whole, frac := s, ""
+	if i := strings.Index(s, "."); i >= 0 {
+		whole, frac = s[:i], s[i+1:]
+	}
+
+	//thousands separators must be in groups of three
+	if strings.Contains(whole, ",") {
+		groups := strings.Split(whole, ",")
+		for i, g := range groups {
+			if (i == 0 && (len(g) == 0 || len(g) > 3)) || (i > 0 && len(g) != 3) {
+				err = errors.New("ward.parseCents - misplaced comma in " + s)
+				return
+			}
+		}
+		whole = strings.Join(groups, "")
+	}
+
+	if len(frac) > 2 {
+		err = errors.New("ward.parseCents - more than two decimal places in " + s)
+		return
+	}
+	for len(frac) < 2 {
+		frac += "0"
+	}
+	if whole == "" {
+		whole = "0"
+	}
+
+	for _, r := range whole + frac {
+		if r < '0' || r > '9' {
+			err = errors.New("ward.parseCents - invalid amount " + s)
+			return
+		}
+	}
+
+	dollars, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil {
+		err = errors.Wrap(err, "ward.parseCents - invalid amount")
+		return
+	}
+	c, err := strconv.ParseInt(frac, 10, 64)
+	if err != nil {
+		err = errors.Wrap(err, "ward.parseCents - invalid amount")
+		return
+	}
+
+	cents = dollars*100 + c
+	return
+}
+
+//formatCents formats cents as a plain dollar amount with two decimal places and no separators, i.e. 1000.00
+func formatCents(cents int64) string {
+	sign := ""
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+//InsuredAmountCents returns the FullValueInsuredAmount in cents
+//This returns an error if the amount is malformed.  An empty amount is zero.
+func (s PickupRequestShipment) InsuredAmountCents() (cents int64, err error) {
+	if strings.TrimSpace(s.FullValueInsuredAmount) == "" {
+		return
+	}
+
+	return parseCents(s.FullValueInsuredAmount)
+}
+
+//normalizeInsuredAmount rewrites FullValueInsuredAmount as a plain dollar amount, i.e. "$1,000" to "1000.00"
+func (s *PickupRequestShipment) normalizeInsuredAmount() error {
+	if strings.TrimSpace(s.FullValueInsuredAmount) == "" {
+		return nil
+	}
+
+	cents, err := parseCents(s.FullValueInsuredAmount)
+	if err != nil {
+		return err
+	}
+
+	s.FullValueInsuredAmount = formatCents(cents)
+	return nil
+}
+
+//toCents converts a dollar amount from Ward to whole cents, rounding half a cent away from zero
+//This rounds the shortest decimal form of the float, i.e. "1234.565", instead of multiplying by 100, since the
+//float closest to 1234.565 is a hair under it and would round down.
+func toCents(dollars float64) int64 {
+	neg := dollars < 0
+	if neg {
+		dollars = -dollars
+	}
+
+	s := strconv.FormatFloat(dollars, 'f', -1, 64)
+	whole, frac := s, ""
+	if i := strings.Index(s, "."); i >= 0 {
+		whole, frac = s[:i], s[i+1:]
+	}
+	for len(frac) < 3 {
+		frac += "0"
+	}
+
+	w, _ := strconv.ParseInt(whole, 10, 64)
+	c, _ := strconv.ParseInt(frac[:2], 10, 64)
+	cents := w*100 + c
+	if frac[2] >= '5' {
+		cents++
+	}
+
+	if neg {
+		return -cents
+	}
+	return cents
+}
+
+//Money is a dollar amount sent to Ward
+//A float64 is written to xml in exponent form when it is large or small enough, i.e. 1e+06, which Ward rejects.
+//Money is always written with two decimal places instead, i.e. 1000000.00.
+type Money float64
+
+//String returns the amount with two decimal places, rounded half a cent away from zero
+func (m Money) String() string {
+	return formatCents(toCents(float64(m)))
+}
+
+//MarshalXML writes the amount with two decimal places
+func (m Money) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.EncodeElement(m.String(), start)
+}
+
+//NetChargeCents returns the NetCharge in whole cents
+//The dollar amounts are floats to match Ward's xml, which can drift when summed.  Use the cents accessors when
+//adding or comparing amounts.
+func (r RateQuoteResponseResult) NetChargeCents() int64 {
+	return toCents(r.NetCharge)
+}
+
+//DiscountAmountCents returns the DiscountAmount in whole cents, see NetChargeCents
+func (r RateQuoteResponseResult) DiscountAmountCents() int64 {
+	return toCents(r.DiscountAmount)
+}
+
+//FuelSurchargeAmountCents returns the FuelSurchargeAmount in whole cents, see NetChargeCents
+func (r RateQuoteResponseResult) FuelSurchargeAmountCents() int64 {
+	return toCents(r.FuelSurchargeAmount)
+}
+
+//SetFullValueCoverage requests full value coverage for an insured amount in dollars
+//This sets the FullValue flag and formats the amount as Ward expects, i.e. 1000.00.  The amount is rounded to
+//the nearest cent and must be more than zero.
+func (s *PickupRequestShipment) SetFullValueCoverage(amount float64) (err error) {
+	cents := toCents(amount)
+	if cents <= 0 {
+		err = errors.New("ward.SetFullValueCoverage - amount must be more than zero")
+		return
+	}
+
+	s.FullValue = YN(true)
+	s.FullValueInsuredAmount = formatCents(cents)
+	return
+}
+
+//CheckFullValue checks that the FullValue flag and insured amount agree
+//Full value coverage needs an amount and an amount is only used with full value coverage.
+func (s PickupRequestShipment) CheckFullValue() (issues []Issue) {
+	flag := normalizeYN(s.FullValue) == "Y"
+	hasAmount := strings.TrimSpace(s.FullValueInsuredAmount) != ""
+
+	switch {
+	case flag && !hasAmount:
+		issues = append(issues, Issue{Field: "Shipment.FullValueInsuredAmount", Message: "is required when FullValue is Y"})
+	case !flag && hasAmount:
+		issues = append(issues, Issue{Field: "Shipment.FullValue", Message: "must be Y when an insured amount is given"})
+	}
+
+	return
+}
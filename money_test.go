@@ -0,0 +1,149 @@
+package ward
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestSetFullValueCoverage(t *testing.T) {
+	tests := []struct {
+		amount   float64
+		expected string
+		invalid  bool
+	}{
+		{1000, "1000.00", false},
+		{1234.567, "1234.57", false},
+		{0.10, "0.10", false},
+		{0, "", true},
+		{-5, "", true},
+		{0.004, "", true},
+	}
+
+	for _, tt := range tests {
+		var s PickupRequestShipment
+		err := s.SetFullValueCoverage(tt.amount)
+		if tt.invalid {
+			if err == nil {
+				t.Errorf("SetFullValueCoverage(%v): expected an error", tt.amount)
+			}
+			if s.FullValue != "" || s.FullValueInsuredAmount != "" {
+				t.Errorf("SetFullValueCoverage(%v): expected nothing to be set, got %+v", tt.amount, s)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("SetFullValueCoverage(%v): unexpected error: %v", tt.amount, err)
+			continue
+		}
+		if s.FullValue != "Y" || s.FullValueInsuredAmount != tt.expected {
+			t.Errorf("SetFullValueCoverage(%v): expected Y and %s, got %s and %s", tt.amount, tt.expected, s.FullValue, s.FullValueInsuredAmount)
+		}
+		if issues := s.CheckFullValue(); len(issues) != 0 {
+			t.Errorf("SetFullValueCoverage(%v): expected no issues, got %v", tt.amount, issues)
+		}
+	}
+}
+
+func TestCheckFullValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		s     PickupRequestShipment
+		field string
+	}{
+		{"neither", PickupRequestShipment{}, ""},
+		{"no coverage", PickupRequestShipment{FullValue: "N"}, ""},
+		{"flag without amount", PickupRequestShipment{FullValue: "yes"}, "Shipment.FullValueInsuredAmount"},
+		{"amount without flag", PickupRequestShipment{FullValueInsuredAmount: "500.00"}, "Shipment.FullValue"},
+	}
+
+	for _, tt := range tests {
+		issues := tt.s.CheckFullValue()
+		if tt.field == "" {
+			if len(issues) != 0 {
+				t.Errorf("%s: expected no issues, got %v", tt.name, issues)
+			}
+			continue
+		}
+		if len(issues) != 1 || issues[0].Field != tt.field {
+			t.Errorf("%s: expected one issue for %s, got %v", tt.name, tt.field, issues)
+		}
+	}
+}
+
+func TestMoneyXML(t *testing.T) {
+	tests := []struct {
+		amount   Money
+		expected string
+	}{
+		{1000000, "<Amount>1000000.00</Amount>"},
+		{123456789012.34, "<Amount>123456789012.34</Amount>"},
+		{1e15, "<Amount>1000000000000000.00</Amount>"},
+		{0.01, "<Amount>0.01</Amount>"},
+		{0.000001, "<Amount>0.00</Amount>"},
+		{12.345, "<Amount>12.35</Amount>"},
+		{-1e6, "<Amount>-1000000.00</Amount>"},
+	}
+
+	for _, tt := range tests {
+		b, err := xml.Marshal(RateQuoteAccessorialItem{Code: AccessorialLiftgate, Amount: tt.amount})
+		if err != nil {
+			t.Fatalf("%v: unexpected error: %v", float64(tt.amount), err)
+		}
+		if !strings.Contains(string(b), tt.expected) {
+			t.Errorf("%v: expected %s in %s", float64(tt.amount), tt.expected, b)
+		}
+	}
+}
+
+func TestMoneyXMLOmitsZero(t *testing.T) {
+	b, err := xml.Marshal(RateQuoteAccessorialItem{Code: AccessorialLiftgate})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(string(b), "Amount") {
+		t.Fatalf("expected no amount, got %s", b)
+	}
+}
+
+func TestCents(t *testing.T) {
+	tests := []struct {
+		amount float64
+		cents  int64
+	}{
+		{1234.565, 123457},
+		{1234.564, 123456},
+		{0.1 + 0.2, 30},
+		{250.75, 25075},
+		{0.005, 1},
+		{0.004, 0},
+		{-1234.565, -123457},
+		{0, 0},
+	}
+
+	for _, tt := range tests {
+		r := RateQuoteResponseResult{NetCharge: tt.amount, DiscountAmount: tt.amount, FuelSurchargeAmount: tt.amount}
+		if got := r.NetChargeCents(); got != tt.cents {
+			t.Errorf("NetChargeCents of %v = %d, expected %d", tt.amount, got, tt.cents)
+		}
+		if got := r.DiscountAmountCents(); got != tt.cents {
+			t.Errorf("DiscountAmountCents of %v = %d, expected %d", tt.amount, got, tt.cents)
+		}
+		if got := r.FuelSurchargeAmountCents(); got != tt.cents {
+			t.Errorf("FuelSurchargeAmountCents of %v = %d, expected %d", tt.amount, got, tt.cents)
+		}
+	}
+}
+
+func TestNetChargeCentsFromResponse(t *testing.T) {
+	body := strings.Replace(quoteSuccessXML, "<NetCharge>250.75</NetCharge>", "<NetCharge>1234.565</NetCharge>", 1)
+
+	var res RateQuoteResponse
+	if err := unmarshalResponse([]byte(body), &res); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := res.CreateResult.NetChargeCents(); got != 123457 {
+		t.Fatalf("expected 123457 cents, got %d", got)
+	}
+}
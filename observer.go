@@ -0,0 +1,38 @@
+package ward
+
+import "time"
+
+//RequestObserver is called after each call to Ward with the endpoint name (EndpointPickup, EndpointRateQuote,
+//etc.), how long the call took including retries, and the error returned, if any
+type RequestObserver func(op string, duration time.Duration, err error)
+
+//SetRequestObserver sets a function to be called after each call to Ward
+//Use this to record timing metrics or log slow calls.  The observer is called synchronously so it should be
+//quick.  Pass nil to stop observing.
+func SetRequestObserver(o RequestObserver) {
+	defaultClient.SetRequestObserver(o)
+	return
+}
+
+//SetRequestObserver sets a function to be called after each call to Ward, see SetRequestObserver
+func (c *Client) SetRequestObserver(o RequestObserver) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.observer = o
+	return
+}
+
+//observe calls the RequestObserver if one is set
+func (c *Client) observe(op string, duration time.Duration, err error) {
+	c.mu.RLock()
+	o := c.observer
+	c.mu.RUnlock()
+
+	if o == nil {
+		return
+	}
+
+	o(op, duration, err)
+	return
+}
@@ -0,0 +1,68 @@
+package ward
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+//observation is one call to a RequestObserver
+type observation struct {
+	op       string
+	duration time.Duration
+	err      error
+}
+
+func TestRequestObserver(t *testing.T) {
+	var (
+		mu   sync.Mutex
+		seen []observation
+	)
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(10 * time.Millisecond)
+		if r.URL.Path == "/pickup" {
+			w.Write([]byte(pickupEmptyXML))
+			return
+		}
+		w.Write([]byte(quoteSuccessXML))
+	})
+	c.SetRequestObserver(func(op string, duration time.Duration, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		seen = append(seen, observation{op, duration, err})
+	})
+
+	q := testRateQuoteRequest()
+	if _, err := c.RateQuote(&q); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p := testPickupRequest()
+	if _, err := c.RequestPickup(&p); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if len(seen) != 2 {
+		t.Fatalf("expected 2 observations, got %d", len(seen))
+	}
+	if seen[0].op != EndpointRateQuote || seen[0].err != nil {
+		t.Errorf("unexpected rate quote observation %+v", seen[0])
+	}
+	if seen[1].op != EndpointPickup || seen[1].err == nil {
+		t.Errorf("unexpected pickup observation %+v", seen[1])
+	}
+	for _, o := range seen {
+		if o.duration < 10*time.Millisecond {
+			t.Errorf("%s: expected a duration of at least 10ms, got %s", o.op, o.duration)
+		}
+	}
+
+	//nil stops observing
+	c.SetRequestObserver(nil)
+	if _, err := c.RateQuote(&q); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(seen) != 2 {
+		t.Fatalf("expected no more observations, got %d", len(seen))
+	}
+}
@@ -0,0 +1,68 @@
+package ward
+
+import "sort"
+
+//package codes for PickupRequestShipment.PackageCode
+//These are NOT verified against Ward's api documentation, they are common LTL package codes and may not be what
+//Ward expects.  Confirm them with Ward, or use the codes from Ward's website, before relying on them.  A code that
+//isn't listed here is only a validation warning so any code Ward gives you can still be sent.
+const (
+	PackageBag      = "BAG"
+	PackageBale     = "BAL"
+	PackageBox      = "BOX"
+	PackageBundle   = "BDL"
+	PackageCarton   = "CTN"
+	PackageCase     = "CAS"
+	PackageCrate    = "CRT"
+	PackageCylinder = "CYL"
+	PackageDrum     = "DRM"
+	PackagePail     = "PAIL"
+	PackagePallet   = "PLT"
+	PackagePieces   = "PCS"
+	PackageReel     = "REL"
+	PackageRoll     = "ROL"
+	PackageSkid     = "SKD"
+	PackageTote     = "TOT"
+)
+
+//packageCodes is every known package code with a description
+var packageCodes = map[string]string{
+	PackageBag:      "bag",
+	PackageBale:     "bale",
+	PackageBox:      "box",
+	PackageBundle:   "bundle",
+	PackageCarton:   "carton",
+	PackageCase:     "case",
+	PackageCrate:    "crate",
+	PackageCylinder: "cylinder",
+	PackageDrum:     "drum",
+	PackagePail:     "pail",
+	PackagePallet:   "pallet",
+	PackagePieces:   "loose pieces",
+	PackageReel:     "reel",
+	PackageRoll:     "roll",
+	PackageSkid:     "skid",
+	PackageTote:     "tote",
+}
+
+//ValidPackageCode checks if a package code is one of the known codes
+//This only catches typos, the constants themselves are unverified with Ward.
+func ValidPackageCode(code string) bool {
+	_, ok := packageCodes[code]
+	return ok
+}
+
+//PackageCodes returns every known package code, sorted
+func PackageCodes() (codes []string) {
+	for c := range packageCodes {
+		codes = append(codes, c)
+	}
+
+	sort.Strings(codes)
+	return
+}
+
+//PackageCodeDescription returns a human readable description of a package code, or an empty string for unknown codes
+func PackageCodeDescription(code string) string {
+	return packageCodes[code]
+}
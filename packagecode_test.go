@@ -0,0 +1,27 @@
+package ward
+
+import "testing"
+
+func TestPackageCodes(t *testing.T) {
+	codes := PackageCodes()
+	if len(codes) != len(packageCodes) {
+		t.Fatalf("expected %d codes, got %d", len(packageCodes), len(codes))
+	}
+
+	for i, c := range codes {
+		if i > 0 && codes[i-1] >= c {
+			t.Errorf("expected sorted, unique codes, got %v", codes)
+		}
+		if !ValidPackageCode(c) || PackageCodeDescription(c) == "" {
+			t.Errorf("%s: expected a known code with a description", c)
+		}
+	}
+
+	//a pail is easily mistaken for a pallet, make sure they can't be confused
+	if PackageCodeDescription(PackagePail) != "pail" || PackageCodeDescription(PackagePallet) != "pallet" {
+		t.Fatalf("expected distinct pail and pallet codes, got %s and %s", PackagePail, PackagePallet)
+	}
+	if ValidPackageCode("PAL") {
+		t.Fatal("expected PAL to not be a known code, it is too close to pallet")
+	}
+}
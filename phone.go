@@ -0,0 +1,68 @@
+package ward
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+//ErrInvalidTelephone is returned when a telephone number isn't ten digits after removing formatting
+var ErrInvalidTelephone = errors.New("ward - telephone must be ten digits")
+
+//NormalizeTelephone converts a formatted telephone number, i.e. (555) 123-4567, to the ten digits Ward expects
+//Everything but digits is removed.  Anything that isn't then ten digits returns ErrInvalidTelephone, including
+//numbers with a country code (+1 555 123 4567) or an extension, since guessing which digits to drop could
+//silently send the wrong number.
+func NormalizeTelephone(raw string) (phone string, err error) {
+	var b strings.Builder
+	for _, r := range raw {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+
+	phone = b.String()
+	if len(phone) != 10 {
+		phone = ""
+		err = ErrInvalidTelephone
+		return
+	}
+
+	return
+}
+
+//setTelephone normalizes raw and sets field to it, leaving field unchanged if raw isn't valid
+func setTelephone(field *string, raw string) (err error) {
+	phone, err := NormalizeTelephone(raw)
+	if err != nil {
+		return
+	}
+
+	*field = phone
+	return
+}
+
+//SetShipperContactTelephone sets the shipper's telephone from a formatted number, see NormalizeTelephone
+func (s *PickupRequestShipperInformation) SetShipperContactTelephone(raw string) error {
+	return setTelephone(&s.ShipperContactTelephone, raw)
+}
+
+//SetThirdPartyContactTelephone sets the third party's telephone from a formatted number, see NormalizeTelephone
+func (s *PickupRequestShipperInformation) SetThirdPartyContactTelephone(raw string) error {
+	return setTelephone(&s.ThirdPartyContactTelephone, raw)
+}
+
+//SetWardAssuredContactTelephone sets the Ward Assured contact's telephone from a formatted number, see NormalizeTelephone
+func (s *PickupRequestShipperInformation) SetWardAssuredContactTelephone(raw string) error {
+	return setTelephone(&s.WardAssuredContactTelephone, raw)
+}
+
+//SetRequestorContactTelephone sets the requestor's telephone from a formatted number, see NormalizeTelephone
+func (s *PickupRequestShipperInformation) SetRequestorContactTelephone(raw string) error {
+	return setTelephone(&s.RequestorContactTelephone, raw)
+}
+
+//SetConsigneeContactTelephone sets the consignee's telephone from a formatted number, see NormalizeTelephone
+func (s *PickupRequestShipment) SetConsigneeContactTelephone(raw string) error {
+	return setTelephone(&s.ConsigneeContactTelephone, raw)
+}
@@ -0,0 +1,57 @@
+package ward
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalizeTelephone(t *testing.T) {
+	tests := []struct {
+		raw      string
+		expected string
+		invalid  bool
+	}{
+		{"5551234567", "5551234567", false},
+		{"(555) 123-4567", "5551234567", false},
+		{"555.123.4567", "5551234567", false},
+		{" 555 123 4567 ", "5551234567", false},
+		{"15551234567", "", true},
+		{"+1 (555) 123-4567", "", true},
+		{"1-555-123-4567", "", true},
+		{"555-1234", "", true},
+		{"555-123-4567 x12", "", true},
+		{"", "", true},
+	}
+
+	for _, tt := range tests {
+		phone, err := NormalizeTelephone(tt.raw)
+		if tt.invalid {
+			if !errors.Is(err, ErrInvalidTelephone) || phone != "" {
+				t.Errorf("NormalizeTelephone(%q) = %q, %v, expected ErrInvalidTelephone", tt.raw, phone, err)
+			}
+			continue
+		}
+
+		if err != nil || phone != tt.expected {
+			t.Errorf("NormalizeTelephone(%q) = %q, %v, expected %q", tt.raw, phone, err, tt.expected)
+		}
+	}
+}
+
+func TestSetTelephone(t *testing.T) {
+	var s PickupRequestShipperInformation
+	if err := s.SetShipperContactTelephone("(555) 123-4567"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.ShipperContactTelephone != "5551234567" {
+		t.Fatalf("expected 5551234567, got %s", s.ShipperContactTelephone)
+	}
+
+	//an invalid number leaves the field unchanged
+	if err := s.SetShipperContactTelephone("1-555-987-6543"); err == nil {
+		t.Fatal("expected an error")
+	}
+	if s.ShipperContactTelephone != "5551234567" {
+		t.Fatalf("expected the telephone to be unchanged, got %s", s.ShipperContactTelephone)
+	}
+}
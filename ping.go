@@ -0,0 +1,52 @@
+package ward
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+//Ping checks if Ward can be reached using the default client, see Client.Ping
+func Ping(ctx context.Context) (latency time.Duration, err error) {
+	return defaultClient.Ping(ctx)
+}
+
+//Ping checks if Ward can be reached and returns how long it took to respond
+//This sends a GET to the rate quote url for the current mode, which doesn't create a pickup or quote.  Any
+//http response, even an error status, means Ward is reachable so only failing to connect or get a response in
+//time returns an error.  Use ctx to limit how long to wait, the timeout set with SetTimeout also applies.
+//Nothing is retried.
+func (c *Client) Ping(ctx context.Context) (latency time.Duration, err error) {
+	req, err := http.NewRequest(http.MethodGet, c.RateQuoteURL(), nil)
+	if err != nil {
+		err = errors.Wrap(err, "ward.Ping - could not build request")
+		return
+	}
+
+	c.addHeaders(ctx, req)
+	err = c.authenticate(req)
+	if err != nil {
+		err = errors.Wrap(err, "ward.Ping - could not authenticate request")
+		return
+	}
+
+	start := c.now()
+	res, err := c.getHTTPClient().Do(req.WithContext(ctx))
+	latency = c.since(start)
+	if err != nil {
+		if ctx.Err() != nil {
+			err = ctx.Err()
+		}
+		err = errors.Wrap(err, "ward.Ping - could not reach Ward")
+		return
+	}
+
+	//drain some of the body so the connection can be reused, Ward may send back a whole service description
+	io.Copy(ioutil.Discard, io.LimitReader(res.Body, 64<<10))
+	res.Body.Close()
+	return
+}
@@ -0,0 +1,51 @@
+package ward
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestPing(t *testing.T) {
+	//any response means Ward is reachable, even an error
+	for _, status := range []int{http.StatusOK, http.StatusInternalServerError} {
+		var method, path string
+		c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			method, path = r.Method, r.URL.Path
+			w.WriteHeader(status)
+		})
+
+		if _, err := c.Ping(context.Background()); err != nil {
+			t.Fatalf("%d: unexpected error: %v", status, err)
+		}
+		if method != http.MethodGet || path != "/quote" {
+			t.Fatalf("%d: expected a GET to the rate quote url, got %s %s", status, method, path)
+		}
+	}
+}
+
+func TestPingSlow(t *testing.T) {
+	c, _ := newTestClient(t, slow(5*time.Second, ""))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	latency, err := c.Ping(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if latency < 50*time.Millisecond || latency > time.Second {
+		t.Fatalf("expected the latency to be about the deadline, got %s", latency)
+	}
+}
+
+func TestPingDown(t *testing.T) {
+	c, srv := newTestClient(t, respond(http.StatusOK, ""))
+	srv.Close()
+
+	if _, err := c.Ping(context.Background()); err == nil {
+		t.Fatal("expected an error when Ward can't be reached")
+	}
+}
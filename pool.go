@@ -0,0 +1,37 @@
+package ward
+
+import (
+	"runtime"
+	"sync"
+)
+
+//runPool calls fn for each index from 0 to n-1 using at most concurrency goroutines at once
+//fn must only write to results for its own index so no locking is needed.  A concurrency of zero or less uses
+//one goroutine per cpu.  This returns once every call has finished.
+func runPool(n, concurrency int, fn func(i int)) {
+	if concurrency <= 0 {
+		concurrency = runtime.NumCPU()
+	}
+	if concurrency > n {
+		concurrency = n
+	}
+
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fn(i)
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+}
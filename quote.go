@@ -0,0 +1,431 @@
+package ward
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+//ErrNoRateOptions is returned when a quote doesn't have any rate details to choose from
+var ErrNoRateOptions = errors.New("ward - quote has no rate options")
+
+//ErrServiceCenterUnavailable is returned by helpers that need service center data when Ward didn't include it
+//in the quote.  This is returned instead of computing a result from zero values.
+var ErrServiceCenterUnavailable = errors.New("ward - service center unavailable")
+
+//reconcileTolerance is how far apart, in dollars, the net charge and summed charges can be and still match
+//this allows for Ward rounding each component to the cent
+const reconcileTolerance = 0.01
+
+//Reconcile checks that the NetCharge matches the sum of the charges that make it up
+//The expected value is the sum of the rate detail amounts and their accessorials, less the discount, plus the
+//fuel surcharge.  The actual value is the NetCharge Ward returned.  ok is false when these differ by more than
+//a cent which means something is off with the quote or with how it was parsed.
+func (r RateQuoteResponseResult) Reconcile() (expected float64, actual float64, ok bool) {
+	for _, d := range r.RateDetails {
+		expected += d.Amount
+
+		for _, a := range d.RateAccessorials {
+			expected += float64(a.Amount)
+		}
+	}
+
+	expected = expected - r.DiscountAmount + r.FuelSurchargeAmount
+	expected = math.Round(expected*100) / 100
+	actual = r.NetCharge
+
+	ok = math.Abs(expected-actual) <= reconcileTolerance+1e-9
+	return
+}
+
+//NormalizedRateDetails returns the rate details sorted by class with split lines for the same class merged
+//Ward can return the rate details in any order and sometimes splits one class over multiple lines.  Merged
+//lines have their weight, pieces, amount, and accessorials combined and keep the rate of the first line.  This
+//is for display only, RateDetails is not modified so the raw data is still available for auditing.
+func (r RateQuoteResponseResult) NormalizedRateDetails() (details []RateQuoteResponseRateDetails) {
+	byClass := map[FreightClass]int{}
+
+	for _, d := range r.RateDetails {
+		i, ok := byClass[d.Class]
+		if !ok {
+			//copy the accessorials so appending for merged lines doesn't modify the raw data
+			d.RateAccessorials = append([]RateQuoteAccessorialItem(nil), d.RateAccessorials...)
+
+			byClass[d.Class] = len(details)
+			details = append(details, d)
+			continue
+		}
+
+		merged := &details[i]
+		merged.Weight += d.Weight
+		merged.Pieces += d.Pieces
+		merged.Amount += d.Amount
+		merged.RateAccessorials = append(merged.RateAccessorials, d.RateAccessorials...)
+	}
+
+	sort.SliceStable(details, func(i, j int) bool {
+		return details[i].Class < details[j].Class
+	})
+
+	return
+}
+
+//OriginCustomerService returns the customer service contact for the origin terminal
+//This falls back to the general customer service contact if Ward didn't provide one for the terminal.
+func (r RateQuoteResponseResult) OriginCustomerService() CustomerServiceContact {
+	if r.OriginServiceCenter.CustomerService.IsZero() {
+		return r.CustomerService
+	}
+
+	return r.OriginServiceCenter.CustomerService
+}
+
+//DestinationCustomerService returns the customer service contact for the destination terminal
+//Use this for delivery issues.  This falls back to the general customer service contact if Ward didn't provide
+//one for the terminal.
+func (r RateQuoteResponseResult) DestinationCustomerService() CustomerServiceContact {
+	if r.DestinationServiceCenter.CustomerService.IsZero() {
+		return r.CustomerService
+	}
+
+	return r.DestinationServiceCenter.CustomerService
+}
+
+//TransitDays returns the number of business days in transit from the destination service center
+//This returns ErrServiceCenterUnavailable if Ward didn't provide the destination service center, rather than
+//zero days which would mean delivery on the day of pickup.
+func (r RateQuoteResponseResult) TransitDays() (days uint, err error) {
+	if r.DestinationServiceCenter.IsZero() {
+		err = ErrServiceCenterUnavailable
+		return
+	}
+
+	days = r.DestinationServiceCenter.TransitDays
+	return
+}
+
+//LaneTransitDays returns the number of business days in transit between the origin and destination
+//Ward returns TransitDays on both service centers and its documentation doesn't say how they combine.  This
+//assumes the destination's is the transit time for the whole lane, from pickup at the origin to delivery, and
+//ignores the origin's.  Check this against your own shipments before relying on it.  This returns zero if Ward
+//didn't provide the destination service center, use TransitDays to tell that apart from same day delivery.
+func (r RateQuoteResponseResult) LaneTransitDays() uint {
+	days, _ := r.TransitDays()
+	return days
+}
+
+//EstimatedDeliveryDate returns the day the freight should be delivered if it ships on shipDate
+//This adds the destination service center's transit days to the ship date, skipping weekends and holidays
+//per SetHolidayCalendar.  A ship date on a non-business day is counted from the next business day.  This
+//returns ErrServiceCenterUnavailable if Ward didn't provide the destination service center.
+func (r RateQuoteResponseResult) EstimatedDeliveryDate(shipDate time.Time) (delivery time.Time, err error) {
+	days, err := r.TransitDays()
+	if err != nil {
+		return
+	}
+
+	delivery = AddBusinessDays(shipDate, int(days))
+	return
+}
+
+//EstimatedDeliveryDate returns the day the freight should be delivered if it ships on shipDate, see
+//RateQuoteResponseResult.EstimatedDeliveryDate
+func (r RateQuoteResponse) EstimatedDeliveryDate(shipDate time.Time) (time.Time, error) {
+	return r.CreateResult.EstimatedDeliveryDate(shipDate)
+}
+
+//AccessorialBreakdown totals the accessorial charges by code across all rate details
+//Ward lists accessorials under each rate detail so the same code can show up more than once.  The amounts are
+//summed in cents, the same as TotalAccessorialCharges.
+func (r RateQuoteResponseResult) AccessorialBreakdown() map[string]float64 {
+	cents := map[string]int64{}
+	for _, d := range r.RateDetails {
+		for _, a := range d.RateAccessorials {
+			cents[string(a.Code)] += toCents(float64(a.Amount))
+		}
+	}
+
+	amounts := map[string]float64{}
+	for code, c := range cents {
+		amounts[code] = float64(c) / 100
+	}
+
+	return amounts
+}
+
+//TotalAccessorialCharges sums every accessorial charge across all rate details
+//The amounts are summed in cents so the total doesn't pick up float rounding errors.
+func (r RateQuoteResponseResult) TotalAccessorialCharges() float64 {
+	var cents int64
+	for _, d := range r.RateDetails {
+		for _, a := range d.RateAccessorials {
+			cents += toCents(float64(a.Amount))
+		}
+	}
+
+	return float64(cents) / 100
+}
+
+//AccessorialBreakdown totals the accessorial charges by code, see RateQuoteResponseResult.AccessorialBreakdown
+func (r RateQuoteResponse) AccessorialBreakdown() map[string]float64 {
+	return r.CreateResult.AccessorialBreakdown()
+}
+
+//TotalAccessorialCharges sums every accessorial charge, see RateQuoteResponseResult.TotalAccessorialCharges
+func (r RateQuoteResponse) TotalAccessorialCharges() float64 {
+	return r.CreateResult.TotalAccessorialCharges()
+}
+
+//AppliedAccessorials lists each accessorial charged across all rate details once, in the order Ward listed them
+//An accessorial under more than one rate detail is merged into one item with the amounts summed in cents.
+//The first non-blank description is kept.
+func (r RateQuoteResponseResult) AppliedAccessorials() (applied []RateQuoteAccessorialItem) {
+	index := map[AccessorialCode]int{}
+	cents := []int64{}
+	for _, d := range r.RateDetails {
+		for _, a := range d.RateAccessorials {
+			i, ok := index[a.Code]
+			if !ok {
+				index[a.Code] = len(applied)
+				applied = append(applied, a)
+				cents = append(cents, toCents(float64(a.Amount)))
+				continue
+			}
+
+			if applied[i].Description == "" {
+				applied[i].Description = a.Description
+			}
+			cents[i] += toCents(float64(a.Amount))
+		}
+	}
+
+	for i := range applied {
+		applied[i].Amount = Money(float64(cents[i]) / 100)
+	}
+
+	return
+}
+
+//AppliedAccessorials lists each accessorial charged once, see RateQuoteResponseResult.AppliedAccessorials
+func (r RateQuoteResponse) AppliedAccessorials() []RateQuoteAccessorialItem {
+	return r.CreateResult.AppliedAccessorials()
+}
+
+//CheapestOption returns the rate detail with the lowest amount
+//Ties go to the first rate detail Ward listed.
+func (r RateQuoteResponse) CheapestOption() (option RateQuoteResponseRateDetails, err error) {
+	details := r.CreateResult.RateDetails
+	if len(details) == 0 {
+		err = ErrNoRateOptions
+		return
+	}
+
+	option = details[0]
+	for _, d := range details[1:] {
+		if toCents(d.Amount) < toCents(option.Amount) {
+			option = d
+		}
+	}
+
+	return
+}
+
+//FastestOption returns the rate detail that delivers soonest, and its transit days
+//Ward gives transit days per service center, see LaneTransitDays, not per rate detail, so every option in a
+//quote is equally fast and ties go to the cheapest option, the same as CheapestOption.  This returns
+//ErrNoRateOptions if there are no rate details and ErrServiceCenterUnavailable if Ward didn't provide the
+//destination service center since the transit days aren't known.
+func (r RateQuoteResponse) FastestOption() (option RateQuoteResponseRateDetails, days uint, err error) {
+	option, err = r.CheapestOption()
+	if err != nil {
+		return
+	}
+
+	days, err = r.CreateResult.TransitDays()
+	if err != nil {
+		option = RateQuoteResponseRateDetails{}
+		return
+	}
+
+	return
+}
+
+//pricingDateLayout is the format of the PricingEffectiveDate, mm/dd/yy
+const pricingDateLayout = "01/02/06"
+
+//ParsedPricingEffectiveDate returns the PricingEffectiveDate as a time, midnight in Ward's timezone
+//Two digit years 69 through 99 are the 1900s and 00 through 68 are the 2000s.
+func (r RateQuoteResponseResult) ParsedPricingEffectiveDate() (effective time.Time, err error) {
+	effective, err = time.ParseInLocation(pricingDateLayout, strings.TrimSpace(r.PricingEffectiveDate), wardLocation)
+	if err != nil {
+		err = errors.Wrap(err, "ward.ParsedPricingEffectiveDate - could not parse pricing effective date")
+		return
+	}
+
+	return
+}
+
+//Expiration returns the last day a quote is good for
+//How long Ward honors a quote depends on your account so the number of business days the quote is valid for
+//is given.  Business days are counted from the pricing effective date skipping weekends and holidays, so a
+//quote from the Friday before a holiday Monday is good until later in the week.
+func (r RateQuoteResponseResult) Expiration(validBusinessDays int) (expires time.Time, err error) {
+	effective, err := r.ParsedPricingEffectiveDate()
+	if err != nil {
+		err = errors.Wrap(err, "ward.Expiration - could not parse pricing effective date")
+		return
+	}
+
+	expires = AddBusinessDays(effective, validBusinessDays)
+	return
+}
+
+//Expired checks if a quote is past its expiration, see Expiration
+func (r RateQuoteResponseResult) Expired(validBusinessDays int) (expired bool, err error) {
+	expires, err := r.Expiration(validBusinessDays)
+	if err != nil {
+		return
+	}
+
+	//quotes are good through the end of the expiration day
+	expired = !now().In(wardLocation).Before(expires.AddDate(0, 0, 1))
+	return
+}
+
+//TotalPieces sums the pieces of every detail item
+//This is what PalletCount is filled in with, see SetAutoPalletCount.
+func (r RateQuoteRequestInner) TotalPieces() (total uint) {
+	for _, d := range r.Details {
+		total += d.Pieces
+	}
+
+	return
+}
+
+//TotalWeight sums the weight of every detail item in lbs
+//Detail items in kilograms are converted to pounds first, the same as when the request is sent.
+func (r RateQuoteRequestInner) TotalWeight() (total uint) {
+	for _, d := range r.Details {
+		total += toPounds(d.Weight, d.WeightUnit)
+	}
+
+	return
+}
+
+//SetAutoPalletCount chooses if a rate quote's PalletCount is filled in from the total of the detail pieces
+//when it is left zero.  This is on by default.
+func SetAutoPalletCount(yes bool) {
+	defaultClient.SetAutoPalletCount(yes)
+	return
+}
+
+//SetAutoPalletCount chooses if a rate quote's PalletCount is filled in, see SetAutoPalletCount
+func (c *Client) SetAutoPalletCount(yes bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.autoPalletCount = yes
+	return
+}
+
+//SetStrictPalletCount chooses if Validate rejects a rate quote whose PalletCount doesn't match the total of the
+//detail pieces
+//Use this to catch data entry mistakes when you set PalletCount yourself.  A zero PalletCount is not checked so
+//it can still be filled in, see SetAutoPalletCount.  This is off by default.
+func SetStrictPalletCount(yes bool) {
+	defaultClient.SetStrictPalletCount(yes)
+	return
+}
+
+//SetStrictPalletCount chooses if a mismatched PalletCount is rejected, see SetStrictPalletCount
+func (c *Client) SetStrictPalletCount(yes bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.strictPalletCount = yes
+	return
+}
+
+//getStrictPalletCount returns if mismatched pallet counts are rejected
+func (c *Client) getStrictPalletCount() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.strictPalletCount
+}
+
+//CheckPalletCount checks that a non-zero PalletCount matches the total of the detail pieces when the default
+//client has strict pallet counts on, see SetStrictPalletCount
+func (r RateQuoteRequest) CheckPalletCount() (issues []Issue) {
+	return r.checkPalletCount(defaultClient.getStrictPalletCount())
+}
+
+//checkPalletCount checks the PalletCount against the detail pieces if strict is true
+func (r RateQuoteRequest) checkPalletCount(strict bool) (issues []Issue) {
+	if !strict || r.Request.PalletCount == 0 {
+		return
+	}
+
+	total := r.Request.TotalPieces()
+	if r.Request.PalletCount != total {
+		issues = append(issues, Issue{
+			Field:   "Request.PalletCount",
+			Message: fmt.Sprintf("is %d but the detail pieces total %d", r.Request.PalletCount, total),
+		})
+	}
+
+	return
+}
+
+//ToPickupRequest builds a pickup request for the shipment that was quoted
+//The origin fills in any blank shipper city, state, zip, and country, the destination becomes the consignee,
+//and the detail items are totalled into the shipment's weight, in pounds, and pieces.  The hazardous and
+//protect from freeze accessorials set the matching flags and Customer fills in a blank ShipperCode.  Pickup
+//specific fields, such as the pickup date and times, are left for the caller to fill in on shipper.
+func (r RateQuoteRequest) ToPickupRequest(shipper PickupRequestShipperInformation) *PickupRequest {
+	q := r.Request
+	p := &PickupRequest{ShipperInfo: shipper}
+
+	s := &p.ShipperInfo
+	fill := []struct {
+		field *string
+		value string
+	}{
+		{&s.ShipperCode, q.Customer},
+		{&s.ShipperCity, q.OriginCity},
+		{&s.ShipperState, q.OriginState},
+		{&s.ShipperZipcode, q.OriginZipcode},
+		{&s.ShipperCountry, q.OriginCountry},
+	}
+	for _, f := range fill {
+		if *f.field == "" {
+			*f.field = f.value
+		}
+	}
+
+	p.Shipment.ConsigneeCity = q.DestinationCity
+	p.Shipment.ConsigneeState = q.DestinationState
+	p.Shipment.ConsigneeZipcode = q.DestinationZipcode
+	p.Shipment.ConsigneeCountry = q.DestinationCountry
+	p.Shipment.DeliveryAppointment = q.DeliveryAppointment
+
+	p.Shipment.Weight = q.TotalWeight()
+	p.Shipment.Pieces = q.TotalPieces()
+
+	p.Shipment.SetHazardous(false)
+	p.Shipment.SetFreezable(false)
+	for _, a := range q.Accessorials {
+		switch a.Code {
+		case AccessorialHazardous:
+			p.Shipment.SetHazardous(true)
+		case AccessorialProtectFromFreeze:
+			p.Shipment.SetFreezable(true)
+		}
+	}
+
+	return p
+}
@@ -0,0 +1,402 @@
+package ward
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCheapestOption(t *testing.T) {
+	r := RateQuoteResponse{CreateResult: RateQuoteResponseResult{RateDetails: []RateQuoteResponseRateDetails{
+		{Class: Class70, Amount: 310.25, Pieces: 2},
+		{Class: Class85, Amount: 295.10, Pieces: 2},
+		{Class: Class100, Amount: 402.00, Pieces: 2},
+	}}}
+
+	option, err := r.CheapestOption()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if option.Class != Class85 {
+		t.Fatalf("expected the class 85 option, got %+v", option)
+	}
+}
+
+func TestFastestOption(t *testing.T) {
+	r := RateQuoteResponse{CreateResult: RateQuoteResponseResult{
+		OriginServiceCenter:      ServiceCenter{ID: 1, Name: "PITTSBURGH", TransitDays: 0},
+		DestinationServiceCenter: ServiceCenter{ID: 2, Name: "CLEVELAND", TransitDays: 2},
+		RateDetails: []RateQuoteResponseRateDetails{
+			{Class: Class70, Amount: 310.25, Pieces: 2},
+			{Class: Class85, Amount: 295.10, Pieces: 2},
+			{Class: Class100, Amount: 402.00, Pieces: 2},
+		},
+	}}
+
+	option, days, err := r.FastestOption()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if days != 2 {
+		t.Fatalf("expected 2 transit days, got %d", days)
+	}
+	if option.Class != Class85 {
+		t.Fatalf("expected the cheapest of the equally fast options, got %+v", option)
+	}
+}
+
+func TestFastestOptionErrors(t *testing.T) {
+	var none RateQuoteResponse
+	if _, _, err := none.FastestOption(); !errors.Is(err, ErrNoRateOptions) {
+		t.Fatalf("expected ErrNoRateOptions, got %v", err)
+	}
+
+	noTransit := RateQuoteResponse{CreateResult: RateQuoteResponseResult{RateDetails: []RateQuoteResponseRateDetails{
+		{Class: Class70, Amount: 310.25},
+	}}}
+	if _, _, err := noTransit.FastestOption(); !errors.Is(err, ErrServiceCenterUnavailable) {
+		t.Fatalf("expected ErrServiceCenterUnavailable, got %v", err)
+	}
+}
+
+func TestCheapestOptionTie(t *testing.T) {
+	r := RateQuoteResponse{CreateResult: RateQuoteResponseResult{RateDetails: []RateQuoteResponseRateDetails{
+		{Class: Class70, Amount: 300},
+		{Class: Class85, Amount: 300},
+	}}}
+
+	option, err := r.CheapestOption()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if option.Class != Class70 {
+		t.Fatalf("expected the first option listed, got %+v", option)
+	}
+}
+
+func TestCheapestOptionNone(t *testing.T) {
+	var r RateQuoteResponse
+	if _, err := r.CheapestOption(); !errors.Is(err, ErrNoRateOptions) {
+		t.Fatalf("expected ErrNoRateOptions, got %v", err)
+	}
+}
+
+func TestLaneTransitDays(t *testing.T) {
+	r := RateQuoteResponseResult{
+		OriginServiceCenter:      ServiceCenter{ID: 1, Name: "PITTSBURGH", TransitDays: 1},
+		DestinationServiceCenter: ServiceCenter{ID: 2, Name: "ATLANTA", TransitDays: 3},
+	}
+
+	//only the destination's transit days are used, see LaneTransitDays
+	if got := r.LaneTransitDays(); got != 3 {
+		t.Fatalf("expected 3 days, got %d", got)
+	}
+
+	//no destination service center is zero days, TransitDays tells that apart from same day delivery
+	r.DestinationServiceCenter = ServiceCenter{}
+	if got := r.LaneTransitDays(); got != 0 {
+		t.Fatalf("expected 0 days, got %d", got)
+	}
+	if _, err := r.TransitDays(); !errors.Is(err, ErrServiceCenterUnavailable) {
+		t.Fatalf("expected ErrServiceCenterUnavailable, got %v", err)
+	}
+}
+
+func TestRateQuoteRequestTotals(t *testing.T) {
+	tests := []struct {
+		name    string
+		details []RateQuoteDetailItem
+		weight  uint
+		pieces  uint
+	}{
+		{"none", nil, 0, 0},
+		{"one", []RateQuoteDetailItem{{Weight: 1000, Pieces: 2}}, 1000, 2},
+		{"several", []RateQuoteDetailItem{{Weight: 1000, Pieces: 2}, {Weight: 250, Pieces: 1}, {Weight: 40, Pieces: 3}}, 1290, 6},
+		{"zero weight", []RateQuoteDetailItem{{Weight: 0, Pieces: 2}, {Weight: 300, Pieces: 0}}, 300, 2},
+		{"all zero", []RateQuoteDetailItem{{}, {}}, 0, 0},
+		{"kilograms", []RateQuoteDetailItem{{Weight: 500, WeightUnit: Kilograms, Pieces: 1}, {Weight: 100, Pieces: 1}}, 1202, 2},
+	}
+
+	for _, tt := range tests {
+		r := RateQuoteRequestInner{Details: tt.details}
+		if got := r.TotalWeight(); got != tt.weight {
+			t.Errorf("%s: expected a total weight of %d, got %d", tt.name, tt.weight, got)
+		}
+		if got := r.TotalPieces(); got != tt.pieces {
+			t.Errorf("%s: expected %d total pieces, got %d", tt.name, tt.pieces, got)
+		}
+	}
+}
+
+func TestParsedPricingEffectiveDate(t *testing.T) {
+	tests := []struct {
+		name     string
+		date     string
+		expected time.Time
+	}{
+		{"current", "03/04/24", time.Date(2024, 3, 4, 0, 0, 0, 0, wardLocation)},
+		{"first of the 2000s", "01/01/00", time.Date(2000, 1, 1, 0, 0, 0, 0, wardLocation)},
+		{"last of the 2000s", "12/31/68", time.Date(2068, 12, 31, 0, 0, 0, 0, wardLocation)},
+		{"first of the 1900s", "01/01/69", time.Date(1969, 1, 1, 0, 0, 0, 0, wardLocation)},
+		{"last of the 1900s", "12/31/99", time.Date(1999, 12, 31, 0, 0, 0, 0, wardLocation)},
+		{"padded", " 03/04/24 ", time.Date(2024, 3, 4, 0, 0, 0, 0, wardLocation)},
+	}
+
+	for _, tt := range tests {
+		r := RateQuoteResponseResult{PricingEffectiveDate: tt.date}
+		got, err := r.ParsedPricingEffectiveDate()
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+			continue
+		}
+		if !got.Equal(tt.expected) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, got)
+		}
+	}
+
+	for _, bad := range []string{"", "2024-03-04", "03/04/2024", "13/01/24"} {
+		r := RateQuoteResponseResult{PricingEffectiveDate: bad}
+		if _, err := r.ParsedPricingEffectiveDate(); err == nil {
+			t.Errorf("%q: expected an error", bad)
+		}
+	}
+}
+
+func TestCheckPalletCount(t *testing.T) {
+	tests := []struct {
+		name    string
+		strict  bool
+		pallets uint
+		issue   bool
+	}{
+		{"strict match", true, 2, false},
+		{"strict mismatch", true, 3, true},
+		{"strict zero", true, 0, false},
+		{"not strict mismatch", false, 3, false},
+	}
+
+	for _, tt := range tests {
+		c := NewClient()
+		c.SetStrictPalletCount(tt.strict)
+
+		q := testRateQuoteRequest()
+		q.Request.PalletCount = tt.pallets
+
+		issues := q.issuesFor(c)
+		if got := hasIssue(issues, "Request.PalletCount"); got != tt.issue {
+			t.Errorf("%s: expected a pallet count issue %t, got %+v", tt.name, tt.issue, issues)
+		}
+	}
+}
+
+func TestStrictPalletCountPerClient(t *testing.T) {
+	strict := NewClient()
+	strict.SetStrictPalletCount(true)
+	strict.SetAutoPalletCount(false)
+
+	q := testRateQuoteRequest()
+	q.Request.PalletCount = 3
+
+	_, err := strict.BuildRateQuoteXML(&q)
+	var v *ValidationError
+	if !errors.As(err, &v) {
+		t.Fatalf("expected a *ValidationError, got %v", err)
+	}
+	if v.Issues[0].Message != "is 3 but the detail pieces total 2" {
+		t.Fatalf("unexpected issue %+v", v.Issues[0])
+	}
+
+	//a zero count is filled in rather than rejected
+	q.Request.PalletCount = 0
+	strict.SetAutoPalletCount(true)
+	if _, err := strict.BuildRateQuoteXML(&q); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	//other clients, and the package level Validate, aren't strict
+	q.Request.PalletCount = 3
+	if _, err := NewClient().BuildRateQuoteXML(&q); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := q.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if issues := q.CheckPalletCount(); len(issues) != 0 {
+		t.Fatalf("expected no issues, got %+v", issues)
+	}
+}
+
+func TestEstimatedDeliveryDate(t *testing.T) {
+	//friday march 8th 2024
+	friday := time.Date(2024, 3, 8, 0, 0, 0, 0, wardLocation)
+
+	tests := []struct {
+		name     string
+		shipDate time.Time
+		days     uint
+		expected time.Time
+	}{
+		{"same day", friday, 0, friday},
+		{"friday crossing the weekend", friday, 1, time.Date(2024, 3, 11, 0, 0, 0, 0, wardLocation)},
+		{"friday crossing the weekend twice", friday, 6, time.Date(2024, 3, 18, 0, 0, 0, 0, wardLocation)},
+		{"saturday counts from monday", friday.AddDate(0, 0, 1), 2, time.Date(2024, 3, 13, 0, 0, 0, 0, wardLocation)},
+	}
+
+	for _, tt := range tests {
+		r := RateQuoteResponse{CreateResult: RateQuoteResponseResult{
+			DestinationServiceCenter: ServiceCenter{ID: 2, Name: "CLEVELAND", TransitDays: tt.days},
+		}}
+
+		got, err := r.EstimatedDeliveryDate(tt.shipDate)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+		if !got.Equal(tt.expected) {
+			t.Errorf("%s: expected %s, got %s", tt.name, tt.expected.Format("Mon 01/02/2006"), got.Format("Mon 01/02/2006"))
+		}
+	}
+}
+
+func TestEstimatedDeliveryDateNoServiceCenter(t *testing.T) {
+	var r RateQuoteResponse
+	if _, err := r.EstimatedDeliveryDate(time.Date(2024, 3, 8, 0, 0, 0, 0, wardLocation)); !errors.Is(err, ErrServiceCenterUnavailable) {
+		t.Fatalf("expected ErrServiceCenterUnavailable, got %v", err)
+	}
+}
+
+//accessorialQuote is a quote with accessorials under more than one rate detail
+var accessorialQuote = RateQuoteResponse{CreateResult: RateQuoteResponseResult{RateDetails: []RateQuoteResponseRateDetails{
+	{Class: Class70, Amount: 300, RateAccessorials: []RateQuoteAccessorialItem{
+		{Code: AccessorialLiftgateDelivery, Amount: 0.1},
+		{Code: AccessorialResidentialDelivery, Description: "RESIDENTIAL DELIVERY", Amount: 85.5},
+	}},
+	{Class: Class85, Amount: 125},
+	{Class: Class100, Amount: 90, RateAccessorials: []RateQuoteAccessorialItem{
+		{Code: AccessorialLiftgateDelivery, Description: "LIFTGATE DELIVERY", Amount: 0.2},
+		{Code: AccessorialInsideDelivery, Amount: 45},
+	}},
+}}}
+
+func TestTotalAccessorialCharges(t *testing.T) {
+	if got := accessorialQuote.TotalAccessorialCharges(); got != 130.8 {
+		t.Fatalf("expected 130.80, got %v", got)
+	}
+
+	var none RateQuoteResponse
+	if got := none.TotalAccessorialCharges(); got != 0 {
+		t.Fatalf("expected 0, got %v", got)
+	}
+}
+
+func TestAccessorialBreakdown(t *testing.T) {
+	expected := map[string]float64{
+		string(AccessorialLiftgateDelivery):    0.3,
+		string(AccessorialResidentialDelivery): 85.5,
+		string(AccessorialInsideDelivery):      45,
+	}
+	if got := accessorialQuote.AccessorialBreakdown(); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+
+	var none RateQuoteResponse
+	if got := none.AccessorialBreakdown(); len(got) != 0 {
+		t.Fatalf("expected no accessorials, got %v", got)
+	}
+}
+
+func TestToPickupRequest(t *testing.T) {
+	q := RateQuoteRequest{Request: RateQuoteRequestInner{
+		Customer:            "12345",
+		OriginCity:          "PITTSBURGH",
+		OriginState:         "PA",
+		OriginZipcode:       "15222",
+		OriginCountry:       CountryUS,
+		DestinationCity:     "TORONTO",
+		DestinationState:    "ON",
+		DestinationZipcode:  "M5V 2T6",
+		DestinationCountry:  CountryCA,
+		DeliveryAppointment: AppointmentRequested,
+		Details: []RateQuoteDetailItem{
+			{Weight: 1000, Pieces: 2, Class: Class70},
+			{Weight: 100, WeightUnit: Kilograms, Pieces: 1, Class: Class85},
+		},
+		Accessorials: []RateQuoteAccessorialItem{{Code: AccessorialHazardous}, {Code: AccessorialLiftgateDelivery}},
+	}}
+
+	p := q.ToPickupRequest(PickupRequestShipperInformation{ShipperName: "ACME WIDGETS"})
+
+	expected := PickupRequestShipperInformation{
+		ShipperCode:    "12345",
+		ShipperName:    "ACME WIDGETS",
+		ShipperCity:    "PITTSBURGH",
+		ShipperState:   "PA",
+		ShipperZipcode: "15222",
+		ShipperCountry: CountryUS,
+	}
+	if !reflect.DeepEqual(p.ShipperInfo, expected) {
+		t.Errorf("unexpected shipper\ngot:      %+v\nexpected: %+v", p.ShipperInfo, expected)
+	}
+
+	s := p.Shipment
+	got := []string{s.ConsigneeCity, s.ConsigneeState, s.ConsigneeZipcode, s.ConsigneeCountry}
+	if want := []string{"TORONTO", "ON", "M5V 2T6", CountryCA}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected consignee %v, got %v", want, got)
+	}
+	if s.DeliveryAppointment != AppointmentRequested {
+		t.Errorf("expected the delivery appointment to be copied, got %q", s.DeliveryAppointment)
+	}
+	if s.Weight != 1220 || s.Pieces != 3 {
+		t.Errorf("expected 1220 lbs and 3 pieces, got %d lbs and %d pieces", s.Weight, s.Pieces)
+	}
+	if s.Hazardous != "Y" || s.Freezable != "N" {
+		t.Errorf("expected hazardous Y and freezable N, got %s and %s", s.Hazardous, s.Freezable)
+	}
+}
+
+func TestToPickupRequestKeepsShipper(t *testing.T) {
+	q := RateQuoteRequest{Request: RateQuoteRequestInner{
+		Customer:      "12345",
+		OriginCity:    "PITTSBURGH",
+		OriginState:   "PA",
+		OriginZipcode: "15222",
+		Accessorials:  []RateQuoteAccessorialItem{{Code: AccessorialProtectFromFreeze}},
+	}}
+
+	shipper := PickupRequestShipperInformation{
+		ShipperCode:    "99999",
+		ShipperCity:    "MONROEVILLE",
+		ShipperState:   "PA",
+		ShipperZipcode: "15146",
+		ShipperCountry: CountryUS,
+	}
+	p := q.ToPickupRequest(shipper)
+
+	if !reflect.DeepEqual(p.ShipperInfo, shipper) {
+		t.Errorf("expected the shipper to be kept\ngot:      %+v\nexpected: %+v", p.ShipperInfo, shipper)
+	}
+	if p.Shipment.Hazardous != "N" || p.Shipment.Freezable != "Y" {
+		t.Errorf("expected hazardous N and freezable Y, got %s and %s", p.Shipment.Hazardous, p.Shipment.Freezable)
+	}
+}
+
+func TestAppliedAccessorials(t *testing.T) {
+	expected := []RateQuoteAccessorialItem{
+		{Code: AccessorialLiftgateDelivery, Description: "LIFTGATE DELIVERY", Amount: 0.3},
+		{Code: AccessorialResidentialDelivery, Description: "RESIDENTIAL DELIVERY", Amount: 85.5},
+		{Code: AccessorialInsideDelivery, Amount: 45},
+	}
+	if got := accessorialQuote.AppliedAccessorials(); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %+v, got %+v", expected, got)
+	}
+
+	//merging doesn't change the response
+	if a := accessorialQuote.CreateResult.RateDetails[0].RateAccessorials[0]; a.Amount != 0.1 || a.Description != "" {
+		t.Fatalf("expected the response to be unchanged, got %+v", a)
+	}
+
+	var none RateQuoteResponse
+	if got := none.AppliedAccessorials(); len(got) != 0 {
+		t.Fatalf("expected no accessorials, got %+v", got)
+	}
+}
@@ -0,0 +1,98 @@
+package ward
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+//quoteCache keeps successful rate quotes in memory for a short time
+type quoteCache struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[string]quoteCacheEntry
+}
+
+//quoteCacheEntry is a cached rate quote and when it stops being used
+type quoteCacheEntry struct {
+	res     RateQuoteResponse
+	expires time.Time
+}
+
+//quoteCacheKey identifies a rate quote by the url and the exact xml sent
+//The xml is built after the request is normalized so, for example, a weight in kg and the same weight in lbs
+//share a key.
+func quoteCacheKey(endpointURL, xmlString string) string {
+	sum := sha256.Sum256([]byte(endpointURL + "\n" + xmlString))
+	return hex.EncodeToString(sum[:])
+}
+
+//get returns a cached rate quote that hasn't expired as of current
+func (q *quoteCache) get(key string, current time.Time) (res RateQuoteResponse, ok bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	e, ok := q.entries[key]
+	if !ok {
+		return
+	}
+	if !current.Before(e.expires) {
+		delete(q.entries, key)
+		ok = false
+		return
+	}
+
+	res = e.res
+	return
+}
+
+//set caches a rate quote as of current, removing any expired quotes so the cache doesn't grow forever
+func (q *quoteCache) set(key string, res RateQuoteResponse, current time.Time) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for k, e := range q.entries {
+		if !current.Before(e.expires) {
+			delete(q.entries, k)
+		}
+	}
+
+	q.entries[key] = quoteCacheEntry{res: res, expires: current.Add(q.ttl)}
+	return
+}
+
+//SetQuoteCache caches successful rate quotes in memory for ttl
+//Identical requests within ttl return the cached quote instead of calling Ward.  Rates only change when the
+//pricing effective date does, so a ttl of a few minutes is safe.  Set NoCache on a request to always get a
+//fresh quote.  Caching is off by default, a ttl of zero turns it off and clears the cache.
+func SetQuoteCache(ttl time.Duration) {
+	defaultClient.SetQuoteCache(ttl)
+	return
+}
+
+//SetQuoteCache caches successful rate quotes in memory for ttl, see SetQuoteCache
+func (c *Client) SetQuoteCache(ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if ttl <= 0 {
+		c.quoteCache = nil
+		return
+	}
+
+	c.quoteCache = &quoteCache{
+		ttl:     ttl,
+		entries: map[string]quoteCacheEntry{},
+	}
+	return
+}
+
+//getQuoteCache returns the rate quote cache, nil if caching is off
+func (c *Client) getQuoteCache() *quoteCache {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.quoteCache
+}
@@ -0,0 +1,109 @@
+package ward
+
+import (
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+//quoteTwice gets the same rate quote twice, the second with the changes made by modify, and returns how many
+//times Ward was called
+func quoteTwice(t *testing.T, ttl time.Duration, modify func(q *RateQuoteRequest)) int32 {
+	t.Helper()
+
+	var calls int32
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Write([]byte(quoteSuccessXML))
+	})
+	c.SetQuoteCache(ttl)
+
+	q := testRateQuoteRequest()
+	if _, err := c.RateQuote(&q); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	q = testRateQuoteRequest()
+	modify(&q)
+	res, err := c.RateQuote(&q)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.CreateResult.QuoteID != "Q98765" {
+		t.Fatalf("unexpected response %+v", res.CreateResult)
+	}
+
+	return calls
+}
+
+func TestQuoteCache(t *testing.T) {
+	tests := []struct {
+		name   string
+		ttl    time.Duration
+		modify func(q *RateQuoteRequest)
+		calls  int32
+	}{
+		{"identical", time.Minute, func(q *RateQuoteRequest) {}, 1},
+		{"different weight", time.Minute, func(q *RateQuoteRequest) {
+			q.Request.Details[0].Weight = 454
+			q.Request.Details[0].WeightUnit = Kilograms
+		}, 2},
+		{"different lane", time.Minute, func(q *RateQuoteRequest) { q.Request.DestinationZipcode = "44102" }, 2},
+		{"no cache", time.Minute, func(q *RateQuoteRequest) { q.NoCache = true }, 2},
+		{"off", 0, func(q *RateQuoteRequest) {}, 2},
+	}
+
+	for _, tt := range tests {
+		if calls := quoteTwice(t, tt.ttl, tt.modify); calls != tt.calls {
+			t.Errorf("%s: expected %d calls to Ward, got %d", tt.name, tt.calls, calls)
+		}
+	}
+}
+
+func TestQuoteCacheWeightUnits(t *testing.T) {
+	//1102 lbs and 500 kg are the same weight once converted so they share a cached quote
+	var n int32
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&n, 1)
+		w.Write([]byte(quoteSuccessXML))
+	})
+	c.SetQuoteCache(time.Minute)
+
+	lbs := testRateQuoteRequest()
+	lbs.Request.Details[0].Weight = 1102
+	kg := testRateQuoteRequest()
+	kg.Request.Details[0].Weight = 500
+	kg.Request.Details[0].WeightUnit = Kilograms
+
+	for _, q := range []RateQuoteRequest{lbs, kg} {
+		if _, err := c.RateQuote(&q); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if n != 1 {
+		t.Fatalf("expected 1 call to Ward, got %d", n)
+	}
+}
+
+func TestQuoteCacheNoCacheRefreshes(t *testing.T) {
+	var calls int32
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Write([]byte(quoteSuccessXML))
+	})
+	c.SetQuoteCache(time.Minute)
+
+	//a NoCache quote is still cached for the next request
+	fresh := testRateQuoteRequest()
+	fresh.NoCache = true
+	cached := testRateQuoteRequest()
+	for _, q := range []RateQuoteRequest{fresh, cached} {
+		if _, err := c.RateQuote(&q); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("expected 1 call to Ward, got %d", calls)
+	}
+}
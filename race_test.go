@@ -0,0 +1,52 @@
+package ward
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+//TestConcurrentSettings changes settings while requests are in progress, run with -race
+func TestConcurrentSettings(t *testing.T) {
+	c, srv := newTestClient(t, respond(http.StatusOK, quoteSuccessXML))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+
+		go func(i int) {
+			defer wg.Done()
+
+			c.SetTimeout(time.Duration(i+1) * time.Second)
+			c.SetRetries(0, time.Millisecond)
+			c.SetProductionMode(false)
+			c.SetRateQuoteURLs(srv.URL+"/quote", srv.URL+"/quote")
+			c.SetContentType(ContentTypeLegacy)
+			c.SetDefaultHeaders(map[string]string{"X-Test": "1"})
+			c.SetLogger(nil)
+			c.SetClock(nil)
+			c.SetEndpointConfig(EndpointRateQuote, EndpointConfig{})
+			c.SetStrictPalletCount(false)
+			c.SetServiceHours(defaultServiceHours)
+			c.SetHolidayCalendar(USFederalHolidays{})
+			c.SetClassResolver(nil)
+			c.SetAllowUnverifiedAccessorials(false)
+		}(i)
+
+		go func() {
+			defer wg.Done()
+
+			q := testRateQuoteRequest()
+			if _, err := c.RateQuote(&q); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			p := testPickupRequest()
+			p.Validate()
+			c.IsBusinessDay(time.Now())
+		}()
+	}
+
+	wg.Wait()
+}
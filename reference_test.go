@@ -0,0 +1,72 @@
+package ward
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+//pickupReferenceXML is a response to a pickup with the requestor reference echoed back
+const pickupReferenceXML = `<?xml version="1.0" encoding="utf-8"?>
+<soap:Envelope xmlns:soap="http://www.w3.org/2003/05/soap-envelope"><soap:Body><CreateResponse><CreateResult>
+<PickupConfirmation>PU123456</PickupConfirmation><Message>%MESSAGE%</Message><PickupTerminal>PIT</PickupTerminal>
+<WardTelephone>8005550100</WardTelephone><WardEmail>pit@example.com</WardEmail>
+<RequestorReference>%REFERENCE%</RequestorReference>
+</CreateResult></CreateResponse></soap:Body></soap:Envelope>`
+
+func TestReferenceMatches(t *testing.T) {
+	tests := []struct {
+		echoed  string
+		sent    string
+		matches bool
+	}{
+		{"PO-1001", "PO-1001", true},
+		{" PO-1001 ", "PO-1001", true},
+		{"", "PO-1001", true},
+		{"", "", true},
+		{"PO-1002", "PO-1001", false},
+		{"PO-1001", "", false},
+		{"po-1001", "PO-1001", false},
+	}
+
+	for _, tt := range tests {
+		r := PickupRequestResponseResult{RequestorReference: tt.echoed}
+		if got := r.ReferenceMatches(tt.sent); got != tt.matches {
+			t.Errorf("ReferenceMatches(%q) with %q echoed = %v, expected %v", tt.sent, tt.echoed, got, tt.matches)
+		}
+	}
+}
+
+func TestRequestPickupReference(t *testing.T) {
+	tests := []struct {
+		name     string
+		echoed   string
+		message  string
+		warnings []string
+	}{
+		{"matches", "PO-1001", "", nil},
+		{"not echoed", "", "", nil},
+		{"mismatch", "PO-2002", "", []string{"requestor reference PO-2002 does not match PO-1001"}},
+		{"mismatch with caveat", "PO-2002", "DRIVER MAY BE LATE", []string{"DRIVER MAY BE LATE", "requestor reference PO-2002 does not match PO-1001"}},
+	}
+
+	for _, tt := range tests {
+		body := strings.NewReplacer("%REFERENCE%", tt.echoed, "%MESSAGE%", tt.message).Replace(pickupReferenceXML)
+		c := frozenClient(t, respond(http.StatusOK, body))
+
+		p := testPickupRequest()
+		p.Shipment.RequestorReference = "PO-1001"
+
+		res, err := c.RequestPickup(&p)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+		if res.CreateResult.RequestorReference != tt.echoed {
+			t.Errorf("%s: expected the echoed reference %q, got %q", tt.name, tt.echoed, res.CreateResult.RequestorReference)
+		}
+		if got := res.Warnings(); !reflect.DeepEqual(got, tt.warnings) {
+			t.Errorf("%s: expected warnings %q, got %q", tt.name, tt.warnings, got)
+		}
+	}
+}
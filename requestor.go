@@ -0,0 +1,53 @@
+package ward
+
+//Requestor identifies who or what system is making requests to Ward
+//This is how requests are attributed in Ward's portal.
+type Requestor struct {
+	Origin           string //sets RequestOrigin
+	User             string
+	Role             string
+	ContactName      string
+	ContactTelephone string //xxxxxxxxxx, only numbers
+	ContactEmail     string
+}
+
+//SetRequestor sets the requestor used for every pickup request
+//Fields on a request that are already set are not changed, so a request can still override these.
+func SetRequestor(r Requestor) {
+	defaultClient.SetRequestor(r)
+	return
+}
+
+//SetRequestor sets the requestor used for every pickup request, see SetRequestor
+func (c *Client) SetRequestor(r Requestor) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.requestor = r
+	return
+}
+
+//applyRequestor fills in any empty requestor fields from the requestor
+func (p *PickupRequest) applyRequestor(r Requestor) {
+	s := &p.ShipperInfo
+
+	fill := []struct {
+		field *string
+		value string
+	}{
+		{&s.RequestOrigin, r.Origin},
+		{&s.RequestorUser, r.User},
+		{&s.RequestorRole, r.Role},
+		{&s.RequestorContactName, r.ContactName},
+		{&s.RequestorContactTelephone, r.ContactTelephone},
+		{&s.RequestorContactEmail, r.ContactEmail},
+		{&p.Shipment.RequestOrigin, r.Origin},
+	}
+	for _, f := range fill {
+		if *f.field == "" {
+			*f.field = f.value
+		}
+	}
+
+	return
+}
@@ -0,0 +1,96 @@
+package ward
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+//ErrorCategory is a broad grouping of errors for logging and alerting
+type ErrorCategory string
+
+//categories of errors
+const (
+	ErrorCategoryNone       ErrorCategory = ""
+	ErrorCategoryValidation ErrorCategory = "validation" //request was invalid and not sent
+	ErrorCategoryTimeout    ErrorCategory = "timeout"    //Ward took too long to respond
+	ErrorCategoryNetwork    ErrorCategory = "network"    //could not connect to Ward or the connection dropped
+	ErrorCategoryResponse   ErrorCategory = "response"   //Ward responded but the response couldn't be used
+)
+
+//CallResult is a summary of a call to Ward with the same structure for every type of call
+//Use this to log every interaction with Ward the same way.
+type CallResult struct {
+	Endpoint      string        //EndpointPickup, EndpointRateQuote, etc.
+	Success       bool          //true when err was nil
+	Reference     string        //pickup confirmation number or quote id
+	NetCharge     float64       //only for rate quotes
+	Message       string        //message or warning from Ward
+	Duration      time.Duration //how long the call took, including retries
+	ErrorCategory ErrorCategory
+	Error         string
+}
+
+//Result summarizes a pickup request, pass the error returned from RequestPickup
+func (r PickupRequestResponse) Result(err error) CallResult {
+	c := newCallResult(EndpointPickup, r.Duration, err)
+	c.Reference = r.CreateResult.PickupConfirmation
+	c.Message = r.CreateResult.Message
+	return c
+}
+
+//Result summarizes a rate quote, pass the error returned from RateQuote
+func (r RateQuoteResponse) Result(err error) CallResult {
+	c := newCallResult(EndpointRateQuote, r.Duration, err)
+	c.Reference = r.CreateResult.QuoteID
+	c.NetCharge = r.CreateResult.NetCharge
+	return c
+}
+
+//newCallResult fills in the parts of a CallResult common to every endpoint
+func newCallResult(endpoint string, d time.Duration, err error) CallResult {
+	c := CallResult{
+		Endpoint:      endpoint,
+		Success:       err == nil,
+		Duration:      d,
+		ErrorCategory: categorizeError(err),
+	}
+	if err != nil {
+		c.Error = err.Error()
+	}
+
+	return c
+}
+
+//categorizeError sorts an error into an ErrorCategory
+func categorizeError(err error) ErrorCategory {
+	if err == nil {
+		return ErrorCategoryNone
+	}
+
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		return ErrorCategoryValidation
+	}
+
+	//a ctx deadline passing is a timeout the same as the operation timeout
+	if errors.Is(err, ErrTimeout) || errors.Is(err, context.DeadlineExceeded) {
+		return ErrorCategoryTimeout
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		if netErr.Timeout() {
+			return ErrorCategoryTimeout
+		}
+		return ErrorCategoryNetwork
+	}
+
+	if errors.Is(err, ErrTruncatedResponse) {
+		return ErrorCategoryNetwork
+	}
+
+	return ErrorCategoryResponse
+}
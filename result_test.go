@@ -0,0 +1,50 @@
+package ward
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestCategorizeError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		category ErrorCategory
+	}{
+		{"nil", nil, ErrorCategoryNone},
+		{"validation", errors.Wrap(&ValidationError{}, "ward.BuildPickupXML - invalid request"), ErrorCategoryValidation},
+		{"operation timeout", errors.Wrap(ErrTimeout, "ward.RateQuote - could not make request"), ErrorCategoryTimeout},
+		{"deadline exceeded", errors.Wrap(context.DeadlineExceeded, "ward.RateQuote - could not make request"), ErrorCategoryTimeout},
+		{"truncated", ErrTruncatedResponse, ErrorCategoryNetwork},
+		{"http error", &HTTPError{StatusCode: http.StatusInternalServerError}, ErrorCategoryResponse},
+	}
+
+	for _, tt := range tests {
+		if got := categorizeError(tt.err); got != tt.category {
+			t.Errorf("%s: expected %q, got %q", tt.name, tt.category, got)
+		}
+	}
+}
+
+func TestResultContextDeadline(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+		w.Write([]byte(quoteSuccessXML))
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	q := testRateQuoteRequest()
+	res, err := c.RateQuoteContext(ctx, &q)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if got := res.Result(err).ErrorCategory; got != ErrorCategoryTimeout {
+		t.Fatalf("expected %q, got %q for %v", ErrorCategoryTimeout, got, err)
+	}
+}
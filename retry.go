@@ -0,0 +1,73 @@
+package ward
+
+import (
+	"net"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+//ErrTimeout is returned when a request, including any retries, takes longer than the operation timeout
+var ErrTimeout = errors.New("ward - operation timed out")
+
+//SetRetries sets how many times a request is retried after a transient failure, with a backoff that doubles
+//before each retry.  Only transient failures are retried (dropped connections, network errors, 502/503/504 responses).  Pickup
+//requests are only retried when they have an IdempotencyKey since a request that reached Ward but whose
+//response was lost would be sent again and may schedule a duplicate pickup.  Bills of lading are never retried
+//for the same reason.
+func SetRetries(n int, backoff time.Duration) {
+	defaultClient.SetRetries(n, backoff)
+	return
+}
+
+//SetRetries sets how many times a request is retried after a transient failure, see SetRetries
+func (c *Client) SetRetries(n int, backoff time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if n < 0 {
+		n = 0
+	}
+
+	c.retries = n
+	c.retryBackoff = backoff
+	return
+}
+
+//SetOperationTimeout caps the total time a request can take, including retries and the backoff between them
+//This is separate from the timeout set with SetTimeout, which applies to each attempt.  When this runs out,
+//even in the middle of an attempt or waiting to retry, ErrTimeout is returned.  An attempt is cut off by
+//whichever runs out first.  A deadline on the ctx given to RateQuoteContext or RequestPickupContext applies
+//as well, with ctx's error returned when it runs out.  Use zero for no limit.
+func SetOperationTimeout(d time.Duration) {
+	defaultClient.SetOperationTimeout(d)
+	return
+}
+
+//SetOperationTimeout caps the total time a request can take, see SetOperationTimeout
+func (c *Client) SetOperationTimeout(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.operationTimeout = d
+	return
+}
+
+//IsRetryable checks if an error is from a transient failure where trying the same request again could succeed
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if errors.Is(err, ErrTruncatedResponse) || errors.Is(err, ErrEmptyResponse) {
+		return true
+	}
+
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.Temporary()
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
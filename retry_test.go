@@ -0,0 +1,141 @@
+package ward
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+//flaky returns a handler that responds with a 503 the first time and body after that, counting every call
+func flaky(calls *int32, body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		w.Write([]byte(body))
+	}
+}
+
+func TestPickupNotRetriedWithoutIdempotencyKey(t *testing.T) {
+	var calls int32
+	c, _ := newTestClient(t, flaky(&calls, pickupSuccessXML))
+	c.SetRetries(2, time.Millisecond)
+
+	p := testPickupRequest()
+	_, err := c.RequestPickup(&p)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if calls != 1 {
+		t.Fatalf("expected 1 call, got %d", calls)
+	}
+}
+
+func TestPickupRetriedWithIdempotencyKey(t *testing.T) {
+	var calls int32
+	c, _ := newTestClient(t, flaky(&calls, pickupSuccessXML))
+	c.SetRetries(2, time.Millisecond)
+
+	p := testPickupRequest()
+	p.IdempotencyKey = "order-1"
+	res, err := c.RequestPickup(&p)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.CreateResult.PickupConfirmation != "PU123456" {
+		t.Fatalf("unexpected confirmation %q", res.CreateResult.PickupConfirmation)
+	}
+	if calls != 2 {
+		t.Fatalf("expected 2 calls, got %d", calls)
+	}
+}
+
+func TestBillOfLadingNotRetried(t *testing.T) {
+	var calls int32
+	c, srv := newTestClient(t, flaky(&calls, bolSuccessXML))
+	c.SetBillOfLadingURLs(srv.URL+"/bol", srv.URL+"/bol")
+	c.SetRetries(2, time.Millisecond)
+
+	b := testBillOfLadingRequest()
+	_, err := c.SubmitBillOfLading(&b)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if calls != 1 {
+		t.Fatalf("expected 1 call, got %d", calls)
+	}
+}
+
+func TestRateQuoteRetried(t *testing.T) {
+	var calls int32
+	c, _ := newTestClient(t, flaky(&calls, quoteSuccessXML))
+	c.SetRetries(2, time.Millisecond)
+
+	q := testRateQuoteRequest()
+	if _, err := c.RateQuote(&q); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("expected 2 calls, got %d", calls)
+	}
+}
+
+func TestOperationTimeout(t *testing.T) {
+	c, _ := newTestClient(t, respond(http.StatusServiceUnavailable, ""))
+	c.SetRetries(100, 20*time.Millisecond)
+	c.SetOperationTimeout(50 * time.Millisecond)
+
+	q := testRateQuoteRequest()
+	_, err := c.RateQuoteContext(context.Background(), &q)
+	if !errors.Is(err, ErrTimeout) {
+		t.Fatalf("expected ErrTimeout, got %v", err)
+	}
+}
+
+func TestRequestPickupContext(t *testing.T) {
+	c, _ := newTestClient(t, slow(5*time.Second, pickupSuccessXML))
+
+	//a ctx deadline stops the request with ctx's error
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	p := testPickupRequest()
+	_, err := c.RequestPickupContext(ctx, &p)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("expected the request to stop at the ctx deadline, took %s", elapsed)
+	}
+
+	//a cancelled ctx isn't sent
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	p = testPickupRequest()
+	if _, err := c.RequestPickupContext(ctx, &p); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestOperationTimeoutCutsOffAttempt(t *testing.T) {
+	c, _ := newTestClient(t, slow(5*time.Second, pickupSuccessXML))
+	c.SetTimeout(10 * time.Second)
+	c.SetOperationTimeout(50 * time.Millisecond)
+
+	//the operation timeout ends the attempt even though the per-attempt timeout is longer
+	start := time.Now()
+	p := testPickupRequest()
+	_, err := c.RequestPickupContext(context.Background(), &p)
+	if !errors.Is(err, ErrTimeout) {
+		t.Fatalf("expected ErrTimeout, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("expected the request to stop at the operation timeout, took %s", elapsed)
+	}
+}
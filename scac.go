@@ -0,0 +1,28 @@
+package ward
+
+//WardSCAC is Ward Trucking's standard carrier alpha code
+const WardSCAC = "WARD"
+
+//ValidSCAC checks if a standard carrier alpha code is two to four uppercase letters
+func ValidSCAC(s string) bool {
+	if len(s) < 2 || len(s) > 4 {
+		return false
+	}
+
+	for _, r := range s {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+
+	return true
+}
+
+//DefaultRoutingSCAC sets the routing SCAC to Ward's if one wasn't given
+func (s *PickupRequestShipment) DefaultRoutingSCAC() {
+	if s.ShipperRoutingSCAC == "" {
+		s.ShipperRoutingSCAC = WardSCAC
+	}
+
+	return
+}
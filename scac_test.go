@@ -0,0 +1,55 @@
+package ward
+
+import "testing"
+
+func TestValidSCAC(t *testing.T) {
+	tests := []struct {
+		scac  string
+		valid bool
+	}{
+		{WardSCAC, true},
+		{"AB", true},
+		{"ABC", true},
+		{"A", false},
+		{"ABCDE", false},
+		{"", false},
+		{"ward", false},
+		{"WA1D", false},
+		{"WA D", false},
+		{" WARD", false},
+	}
+
+	for _, tt := range tests {
+		if got := ValidSCAC(tt.scac); got != tt.valid {
+			t.Errorf("ValidSCAC(%q) = %v, expected %v", tt.scac, got, tt.valid)
+		}
+	}
+}
+
+func TestDefaultRoutingSCAC(t *testing.T) {
+	var s PickupRequestShipment
+	s.DefaultRoutingSCAC()
+	if s.ShipperRoutingSCAC != WardSCAC {
+		t.Fatalf("expected %s, got %q", WardSCAC, s.ShipperRoutingSCAC)
+	}
+
+	//a SCAC that was given is kept
+	s.ShipperRoutingSCAC = "ABCD"
+	s.DefaultRoutingSCAC()
+	if s.ShipperRoutingSCAC != "ABCD" {
+		t.Fatalf("expected ABCD to be kept, got %q", s.ShipperRoutingSCAC)
+	}
+}
+
+func TestValidateSCAC(t *testing.T) {
+	p := testPickupRequest()
+	p.Shipment.ShipperRoutingSCAC = "wrd1"
+	if !hasIssue(p.issuesFor(NewClient(WithClock(frozenClock(frozenNow)))), "Shipment.ShipperRoutingSCAC") {
+		t.Fatal("expected an issue for Shipment.ShipperRoutingSCAC")
+	}
+
+	p.Shipment.ShipperRoutingSCAC = WardSCAC
+	if hasIssue(p.issuesFor(NewClient(WithClock(frozenClock(frozenNow)))), "Shipment.ShipperRoutingSCAC") {
+		t.Fatal("expected no issue for Ward's SCAC")
+	}
+}
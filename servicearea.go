@@ -0,0 +1,76 @@
+package ward
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+//ErrLaneNotServed is returned when Ward doesn't have a service center for the origin or destination
+var ErrLaneNotServed = errors.New("ward - lane is not served")
+
+//ServiceArea is the service centers that would handle freight between two zip codes
+type ServiceArea struct {
+	OriginZipcode            string
+	DestinationZipcode       string
+	OriginServiceCenter      ServiceCenter
+	DestinationServiceCenter ServiceCenter
+	TransitDays              uint //business days, zero if the lane is not served
+}
+
+//Serviceable checks if Ward has a service center at both ends of the lane
+func (s ServiceArea) Serviceable() bool {
+	return !s.OriginServiceCenter.IsZero() && !s.DestinationServiceCenter.IsZero()
+}
+
+//serviceAreaProbe is the freight quoted to look up a service area
+//Ward doesn't have a separate service area lookup so the smallest reasonable quote is used.  The rate isn't
+//used, only the service centers.
+var serviceAreaProbe = RateQuoteDetailItem{
+	Weight: 100,
+	Pieces: 1,
+	Class:  Class50,
+}
+
+//CheckServiceArea looks up the service centers for a lane using the default client
+func CheckServiceArea(originZip, destinationZip string) (area ServiceArea, err error) {
+	return defaultClient.CheckServiceArea(originZip, destinationZip)
+}
+
+//CheckServiceArea looks up the service centers that would handle freight between two zip codes
+//This is done with a minimal rate quote since Ward returns the service centers with each quote.  The
+//ServiceArea is returned along with ErrLaneNotServed when Ward doesn't service either end so the caller can
+//see which end is missing.
+func (c *Client) CheckServiceArea(originZip, destinationZip string) (area ServiceArea, err error) {
+	area.OriginZipcode = strings.TrimSpace(originZip)
+	area.DestinationZipcode = strings.TrimSpace(destinationZip)
+
+	q := RateQuoteRequest{
+		Request: RateQuoteRequestInner{
+			Details:            []RateQuoteDetailItem{serviceAreaProbe},
+			OriginZipcode:      area.OriginZipcode,
+			DestinationZipcode: area.DestinationZipcode,
+		},
+	}
+
+	res, err := c.RateQuote(&q)
+	if err != nil {
+		err = errors.Wrap(err, "ward.CheckServiceArea - could not look up service area")
+		return
+	}
+
+	area.OriginServiceCenter = res.CreateResult.OriginServiceCenter
+	area.DestinationServiceCenter = res.CreateResult.DestinationServiceCenter
+
+	switch {
+	case area.OriginServiceCenter.IsZero():
+		err = errors.Wrap(ErrLaneNotServed, "ward.CheckServiceArea - no service center for origin "+area.OriginZipcode)
+		return
+	case area.DestinationServiceCenter.IsZero():
+		err = errors.Wrap(ErrLaneNotServed, "ward.CheckServiceArea - no service center for destination "+area.DestinationZipcode)
+		return
+	}
+
+	area.TransitDays = area.DestinationServiceCenter.TransitDays
+	return
+}
@@ -0,0 +1,77 @@
+package ward
+
+import (
+	"context"
+	"log/slog"
+	"regexp"
+	"time"
+)
+
+//redactedFields matches the xml elements holding a person's contact details, the element's text is masked
+//This covers the contact names, telephones, emails, and requestor user of every party, plus the phone and fax
+//numbers in Ward's responses.  An element cut off at the end of the text, as in an error's snippet of a
+//response, is masked too.
+var redactedFields = regexp.MustCompile(`(<[\w:]*(?:ContactName|Telephone|Email|Phone|Fax|RequestorUser)>)[^<]+(</|$)`)
+
+//redactedEmails and redactedPhones match contact details outside of an xml element, i.e. in an error's message
+var (
+	redactedEmails = regexp.MustCompile(`[\w.+-]+@[\w-]+(?:\.[\w-]+)+`)
+	redactedPhones = regexp.MustCompile(`\b\(?\d{3}\)?[-. ]?\d{3}[-. ]?\d{4}\b`)
+)
+
+//redactedValue replaces the text of a redacted element
+const redactedValue = "REDACTED"
+
+//redactXML masks the contact details in a request or response so it can be logged
+func redactXML(xmlString string) string {
+	return redactedFields.ReplaceAllString(xmlString, "${1}"+redactedValue+"${2}")
+}
+
+//redactError masks the contact details in an error's message so it can be logged
+//Errors such as HTTPError include part of Ward's response, which can echo the contact details that were sent.
+func redactError(err error) string {
+	msg := redactXML(err.Error())
+	msg = redactedEmails.ReplaceAllString(msg, redactedValue)
+	return redactedPhones.ReplaceAllString(msg, redactedValue)
+}
+
+//SetDebugLogger sets a structured logger that every call to Ward is logged to at debug level
+//Each call logs the endpoint, how long it took, the http status code, any error, and the request xml.  The
+//contact names, telephones, and emails are masked in both the request and the error.  Pass nil to stop logging.
+func SetDebugLogger(l *slog.Logger) {
+	defaultClient.SetDebugLogger(l)
+	return
+}
+
+//SetDebugLogger sets a structured logger that every call to Ward is logged to, see SetDebugLogger
+func (c *Client) SetDebugLogger(l *slog.Logger) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.debugLogger = l
+	return
+}
+
+//debugLog logs a call to Ward to the debug logger if one is set
+func (c *Client) debugLog(ctx context.Context, endpoint string, duration time.Duration, statusCode int, xmlString string, err error) {
+	c.mu.RLock()
+	l := c.debugLogger
+	c.mu.RUnlock()
+
+	if l == nil {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("endpoint", endpoint),
+		slog.Duration("duration", duration),
+		slog.Int("status", statusCode),
+		slog.String("request", redactXML(xmlString)),
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", redactError(err)))
+	}
+
+	l.LogAttrs(ctx, slog.LevelDebug, "ward request", attrs...)
+	return
+}
@@ -0,0 +1,109 @@
+package ward
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+//contactDetails are the names, users, telephones, and emails set on the test requests, none should be logged
+var contactDetails = []string{
+	"JANE SHIPPER", "4125550100", "jane@example.com",
+	"THIRD PARTY", "4125550101", "third@example.com",
+	"ASSURED CONTACT", "4125550102", "assured@example.com",
+	"REQUESTOR", "4125550103", "requestor@example.com",
+	"2165550104", "REQUSER",
+}
+
+//withContactDetails sets every contact name, telephone, and email on a pickup request
+func withContactDetails(p *PickupRequest) {
+	s := &p.ShipperInfo
+	s.ShipperContactName, s.ShipperContactTelephone, s.ShipperContactEmail = "JANE SHIPPER", "4125550100", "jane@example.com"
+	s.ThirdPartyContactName, s.ThirdPartyContactTelephone, s.ThirdPartyContactEmail = "THIRD PARTY", "4125550101", "third@example.com"
+	s.WardAssuredContactName, s.WardAssuredContactTelephone, s.WardAssuredContactEmail = "ASSURED CONTACT", "4125550102", "assured@example.com"
+	s.RequestorContactName, s.RequestorContactTelephone, s.RequestorContactEmail = "REQUESTOR", "4125550103", "requestor@example.com"
+	s.RequestorUser = "REQUSER"
+	p.Shipment.ConsigneeContactTelephone = "2165550104"
+}
+
+func TestRedactXML(t *testing.T) {
+	p := testPickupRequest()
+	withContactDetails(&p)
+
+	xmlString, err := NewClient().BuildPickupXML(&p)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, c := range contactDetails {
+		if !strings.Contains(xmlString, c) {
+			t.Fatalf("expected %s in the request before it is redacted", c)
+		}
+	}
+
+	redacted := redactXML(xmlString)
+	for _, c := range contactDetails {
+		if strings.Contains(redacted, c) {
+			t.Errorf("expected %s to be masked in\n%s", c, redacted)
+		}
+	}
+	if !strings.Contains(redacted, "<ShipperContactEmail>"+redactedValue+"</ShipperContactEmail>") {
+		t.Errorf("expected the element to be kept with its text masked in\n%s", redacted)
+	}
+	if !strings.Contains(redacted, "<ShipperZipcode>15222</ShipperZipcode>") {
+		t.Errorf("expected other fields to be left alone in\n%s", redacted)
+	}
+}
+
+func TestDebugLogger(t *testing.T) {
+	//Ward's error page echoes the contact details that were sent, cut off partway through a telephone
+	body := `<Error><ShipperContactEmail>jane@example.com</ShipperContactEmail> call jane at (412) 555-0100 ` +
+		`<ShipperContactName>JANE SHIPPER</ShipperContactName><ShipperContactTelephone>4125550100</ShipperContactTelephone></Error>`
+	c, _ := newTestClient(t, respond(http.StatusInternalServerError, body))
+
+	var buf bytes.Buffer
+	c.SetDebugLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+
+	p := testPickupRequest()
+	withContactDetails(&p)
+	if _, err := c.RequestPickup(&p); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	out := buf.String()
+	for _, s := range append(contactDetails, "555-0100") {
+		if strings.Contains(out, s) {
+			t.Errorf("expected %s to be masked in\n%s", s, out)
+		}
+	}
+
+	var entry struct {
+		Msg      string `json:"msg"`
+		Endpoint string `json:"endpoint"`
+		Status   int    `json:"status"`
+		Request  string `json:"request"`
+		Error    string `json:"error"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if entry.Msg != "ward request" || entry.Endpoint != EndpointPickup || entry.Status != http.StatusInternalServerError {
+		t.Errorf("unexpected log entry %+v", entry)
+	}
+	if !strings.Contains(entry.Request, redactedValue) || !strings.Contains(entry.Error, redactedValue) {
+		t.Errorf("expected the request and error to be masked, got %+v", entry)
+	}
+}
+
+func TestRedactError(t *testing.T) {
+	err := &HTTPError{StatusCode: http.StatusBadRequest, Body: []byte(strings.Repeat("x", 180) + "<WardTelephone>8005550100</WardTelephone>")}
+	if !strings.Contains(err.Error(), "80055") {
+		t.Fatalf("expected the snippet to include part of the telephone, got %s", err.Error())
+	}
+
+	if got := redactError(err); strings.Contains(got, "80055") {
+		t.Fatalf("expected the cut off telephone to be masked, got %s", got)
+	}
+}
@@ -0,0 +1,288 @@
+package ward
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+)
+
+//SOAPVersion is the version of SOAP used to build request envelopes
+type SOAPVersion int
+
+//supported SOAP versions
+const (
+	SOAP12 SOAPVersion = iota //default, what Ward's documentation uses
+	SOAP11                    //fallback for older Ward services that reject a SOAP 1.2 envelope
+)
+
+//SetSOAPVersion sets the version of SOAP used for requests
+//Use SOAP11 if an endpoint rejects the SOAP 1.2 envelope.
+func SetSOAPVersion(v SOAPVersion) {
+	defaultClient.SetSOAPVersion(v)
+	return
+}
+
+//SetSOAPVersion sets the version of SOAP used for requests, see SetSOAPVersion
+func (c *Client) SetSOAPVersion(v SOAPVersion) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.soapVersion = v
+	return
+}
+
+//EnvelopeNamespaces is the namespace prefix and namespaces used on the SOAP envelope
+//Blank fields use the defaults for the SOAP version, see SetEnvelopeNamespaces.
+type EnvelopeNamespaces struct {
+	Prefix   string //prefix of the Envelope and Body elements, i.e. soap12
+	Envelope string //the SOAP envelope namespace, i.e. http://www.w3.org/2003/05/soap-envelope
+	XSI      string //the xml schema instance namespace
+	XSD      string //the xml schema namespace
+}
+
+//namespaces returns the default envelope namespaces for the SOAP version
+func (v SOAPVersion) namespaces() EnvelopeNamespaces {
+	if v == SOAP11 {
+		return EnvelopeNamespaces{Prefix: "soap", Envelope: soap11Attr, XSI: xsiAttr, XSD: xsdAttr}
+	}
+
+	return EnvelopeNamespaces{Prefix: "soap12", Envelope: soap12Attr, XSI: xsiAttr, XSD: xsdAttr}
+}
+
+//withDefaults fills in any blank fields with the defaults for the SOAP version
+func (n EnvelopeNamespaces) withDefaults(v SOAPVersion) EnvelopeNamespaces {
+	d := v.namespaces()
+	if n.Prefix == "" {
+		n.Prefix = d.Prefix
+	}
+	if n.Envelope == "" {
+		n.Envelope = d.Envelope
+	}
+	if n.XSI == "" {
+		n.XSI = d.XSI
+	}
+	if n.XSD == "" {
+		n.XSD = d.XSD
+	}
+
+	return n
+}
+
+//SetEnvelopeNamespaces overrides the namespace prefix and namespaces used on the SOAP envelope
+//The defaults match the SOAP version set with SetSOAPVersion, only use this if Ward requires something
+//different.  Pass a zero EnvelopeNamespaces to go back to the defaults.
+func SetEnvelopeNamespaces(n EnvelopeNamespaces) {
+	defaultClient.SetEnvelopeNamespaces(n)
+	return
+}
+
+//SetEnvelopeNamespaces overrides the namespaces used on the SOAP envelope, see SetEnvelopeNamespaces
+func (c *Client) SetEnvelopeNamespaces(n EnvelopeNamespaces) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.envelopeNamespaces = n
+	return
+}
+
+//contentType returns the Content-Type header to send with a raw xml body
+//SOAP 1.2 uses what Ward's demo used, SOAP 1.1 requires text/xml.
+func (v SOAPVersion) contentType() string {
+	if v == SOAP11 {
+		return ContentTypeSOAP11
+	}
+
+	return ContentTypeLegacy
+}
+
+//marshalEnvelope builds a SOAP envelope with body as the request element
+func marshalEnvelope(ns EnvelopeNamespaces, body interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	e := xml.NewEncoder(&buf)
+
+	if err := encodeEnvelope(e, ns, body); err != nil {
+		return nil, err
+	}
+	if err := e.Flush(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+//encodeEnvelope writes a SOAP envelope with body as the request element
+//This is the one place the envelope is built for every type of request.
+func encodeEnvelope(e *xml.Encoder, ns EnvelopeNamespaces, body interface{}) (err error) {
+	envelope := xml.StartElement{
+		Name: xml.Name{Local: ns.Prefix + ":Envelope"},
+		Attr: []xml.Attr{
+			{Name: xml.Name{Local: "xmlns:xsi"}, Value: ns.XSI},
+			{Name: xml.Name{Local: "xmlns:xsd"}, Value: ns.XSD},
+			{Name: xml.Name{Local: "xmlns:" + ns.Prefix}, Value: ns.Envelope},
+		},
+	}
+	soapBody := xml.StartElement{Name: xml.Name{Local: ns.Prefix + ":Body"}}
+
+	if err = e.EncodeToken(envelope); err != nil {
+		return
+	}
+	if err = e.EncodeToken(soapBody); err != nil {
+		return
+	}
+	if err = e.EncodeElement(body, xml.StartElement{Name: xml.Name{Local: "request"}}); err != nil {
+		return
+	}
+	if err = e.EncodeToken(soapBody.End()); err != nil {
+		return
+	}
+
+	err = e.EncodeToken(envelope.End())
+	return
+}
+
+//envelopeBody returns the contents of the request element for a pickup request
+func (p PickupRequest) envelopeBody() interface{} {
+	//hazmat details are only sent for hazardous shipments
+	if normalizeYN(p.Shipment.Hazardous) != "Y" {
+		p.Shipment.Hazmat = nil
+	}
+
+	return struct {
+		ShipperInfo PickupRequestShipperInformation `xml:"ShipperInformation"`
+		Shipment    PickupRequestShipment           `xml:"Shipment"`
+	}{
+		ShipperInfo: p.ShipperInfo,
+		Shipment:    p.Shipment,
+	}
+}
+
+//MarshalXML builds the SOAP envelope around the pickup request
+//This always uses SOAP 1.2, a Client builds the envelope for the SOAP version it is set to use.
+func (p PickupRequest) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return encodeEnvelope(e, SOAP12.namespaces(), p.envelopeBody())
+}
+
+//MarshalXML builds the SOAP envelope around the rate quote request
+//This always uses SOAP 1.2, a Client builds the envelope for the SOAP version it is set to use.
+func (p RateQuoteRequest) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return encodeEnvelope(e, SOAP12.namespaces(), p.Request)
+}
+
+//SOAPFault is returned when Ward responds with a SOAP fault instead of a result
+//This is Ward's explanation of why the request failed.  Both SOAP 1.1 and SOAP 1.2 faults are read into the
+//same fields.
+type SOAPFault struct {
+	StatusCode int    //http status code of Ward's response
+	Code       string //i.e. soap:Client or soap:Server, SOAP 1.2 subcodes are appended after a slash
+	Reason     string
+	Detail     string
+	Body       []byte //the raw response
+}
+
+//Error implements the error interface
+func (f *SOAPFault) Error() string {
+	msg := "ward - soap fault"
+	if f.Code != "" {
+		msg += " " + f.Code
+	}
+	if f.Reason != "" {
+		msg += ": " + f.Reason
+	}
+
+	return msg
+}
+
+//soapFaultEnvelope is used to read a fault from either SOAP version
+type soapFaultEnvelope struct {
+	Fault *struct {
+		//SOAP 1.1
+		FaultCode   string      `xml:"faultcode"`
+		FaultString string      `xml:"faultstring"`
+		FaultDetail faultDetail `xml:"detail"`
+
+		//SOAP 1.2
+		Code    string      `xml:"Code>Value"`
+		Subcode string      `xml:"Code>Subcode>Value"`
+		Reason  string      `xml:"Reason>Text"`
+		Detail  faultDetail `xml:"Detail"`
+	} `xml:"Body>Fault"`
+}
+
+//faultDetail keeps the raw contents of a fault's detail since it is often more xml
+type faultDetail struct {
+	Inner string `xml:",innerxml"`
+}
+
+//parseSOAPFault reads a SOAP fault from a response body, returning nil if the body isn't a fault
+func parseSOAPFault(body []byte, statusCode int) *SOAPFault {
+	var env soapFaultEnvelope
+	if err := unmarshalResponse(body, &env); err != nil || env.Fault == nil {
+		return nil
+	}
+
+	f := env.Fault
+	fault := &SOAPFault{
+		StatusCode: statusCode,
+		Code:       strings.TrimSpace(f.FaultCode),
+		Reason:     strings.TrimSpace(f.FaultString),
+		Detail:     strings.TrimSpace(f.FaultDetail.Inner),
+		Body:       body,
+	}
+	if fault.Code == "" {
+		fault.Code = strings.TrimSpace(f.Code)
+		if sub := strings.TrimSpace(f.Subcode); sub != "" {
+			fault.Code += "/" + sub
+		}
+	}
+	if fault.Reason == "" {
+		fault.Reason = strings.TrimSpace(f.Reason)
+	}
+	if fault.Detail == "" {
+		fault.Detail = strings.TrimSpace(f.Detail.Inner)
+	}
+
+	return fault
+}
+
+//SetXMLFraming chooses if the xml declaration is put before, and a blank line after, each request's envelope
+//Both are on by default since Ward's service has not accepted requests without them.  This is only here in
+//case that changes or an endpoint set up with SetEndpointConfig is stricter.
+func SetXMLFraming(header, trailingNewline bool) {
+	defaultClient.SetXMLFraming(header, trailingNewline)
+	return
+}
+
+//SetXMLFraming chooses if the xml declaration and a trailing blank line are sent, see SetXMLFraming
+func (c *Client) SetXMLFraming(header, trailingNewline bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.xmlHeader = header
+	c.trailingNewline = trailingNewline
+	return
+}
+
+//buildSOAPBody builds the body of a request to Ward with body as the request element
+//This is the SOAP envelope for the client's SOAP version with the xml declaration before it and a newline after
+//it.  Ward needs both to get requests to work for some reason, see SetXMLFraming.
+func (c *Client) buildSOAPBody(body interface{}) (xmlString string, err error) {
+	c.mu.RLock()
+	ns := c.envelopeNamespaces.withDefaults(c.soapVersion)
+	header, trailingNewline := c.xmlHeader, c.trailingNewline
+	c.mu.RUnlock()
+
+	xmlBytes, err := marshalEnvelope(ns, body)
+	if err != nil {
+		return
+	}
+
+	xmlString = string(xmlBytes)
+	if header {
+		xmlString = xml.Header + xmlString
+	}
+	if trailingNewline {
+		xmlString += "\n"
+	}
+
+	return
+}
@@ -0,0 +1,88 @@
+package ward
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestMarshalEnvelopeNamespaces(t *testing.T) {
+	ns := EnvelopeNamespaces{
+		Prefix:   "env",
+		Envelope: "urn:example:envelope",
+		XSI:      "urn:example:xsi",
+		XSD:      "urn:example:xsd",
+	}
+	body := struct {
+		QuoteID string `xml:"QuoteID"`
+	}{"Q98765"}
+
+	b, err := marshalEnvelope(ns, body)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `<env:Envelope xmlns:xsi="urn:example:xsi" xmlns:xsd="urn:example:xsd" xmlns:env="urn:example:envelope">` +
+		`<env:Body><request><QuoteID>Q98765</QuoteID></request></env:Body></env:Envelope>`
+	if string(b) != expected {
+		t.Fatalf("got:\n%s\nexpected:\n%s", b, expected)
+	}
+}
+
+func TestSetEnvelopeNamespaces(t *testing.T) {
+	tests := []struct {
+		name     string
+		version  SOAPVersion
+		ns       EnvelopeNamespaces
+		expected []string
+	}{
+		{"defaults", SOAP12, EnvelopeNamespaces{}, []string{
+			`<soap12:Envelope`, `xmlns:soap12="` + soap12Attr + `"`, `<soap12:Body>`,
+		}},
+		{"soap 1.1 defaults", SOAP11, EnvelopeNamespaces{}, []string{
+			`<soap:Envelope`, `xmlns:soap="` + soap11Attr + `"`, `<soap:Body>`,
+		}},
+		{"prefix only", SOAP11, EnvelopeNamespaces{Prefix: "s"}, []string{
+			`<s:Envelope`, `xmlns:s="` + soap11Attr + `"`, `xmlns:xsi="` + xsiAttr + `"`, `<s:Body>`,
+		}},
+		{"envelope only", SOAP12, EnvelopeNamespaces{Envelope: "urn:example:envelope"}, []string{
+			`<soap12:Envelope`, `xmlns:soap12="urn:example:envelope"`, `xmlns:xsd="` + xsdAttr + `"`,
+		}},
+	}
+
+	for _, tt := range tests {
+		c := NewClient()
+		c.SetSOAPVersion(tt.version)
+		c.SetEnvelopeNamespaces(tt.ns)
+
+		xmlString, err := c.buildSOAPBody(getQuoteRequest{QuoteID: "Q98765"})
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+		for _, e := range tt.expected {
+			if !strings.Contains(xmlString, e) {
+				t.Errorf("%s: expected %s in\n%s", tt.name, e, xmlString)
+			}
+		}
+	}
+}
+
+func TestRequestMarshalXMLSharesEnvelope(t *testing.T) {
+	p := testPickupRequest()
+	q := testRateQuoteRequest()
+
+	for _, v := range []interface{}{p, q} {
+		b, err := xml.Marshal(v)
+		if err != nil {
+			t.Fatalf("%T: unexpected error: %v", v, err)
+		}
+
+		s := string(b)
+		if !strings.HasPrefix(s, `<soap12:Envelope xmlns:xsi="`+xsiAttr+`" xmlns:xsd="`+xsdAttr+`" xmlns:soap12="`+soap12Attr+`"><soap12:Body><request>`) {
+			t.Errorf("%T: unexpected envelope %s", v, s)
+		}
+		if !strings.HasSuffix(s, `</request></soap12:Body></soap12:Envelope>`) {
+			t.Errorf("%T: unexpected envelope %s", v, s)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package ward
+
+import (
+	"encoding/xml"
+	"strings"
+	"time"
+)
+
+//timestampElements are the element names Ward may use for the server's timestamp in a response
+var timestampElements = map[string]bool{
+	"Timestamp":    true,
+	"TimeStamp":    true,
+	"ServerTime":   true,
+	"ResponseTime": true,
+}
+
+//timestampLayouts are the formats a server timestamp may be in
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"01/02/2006 15:04:05",
+	"01/02/2006 03:04:05 PM",
+	"01/02/06 15:04",
+}
+
+//wardLocation is the timezone for timestamps from Ward that don't include one
+//Ward is based in Pennsylvania.  Fall back to local time if timezone data isn't available.
+var wardLocation = func() *time.Location {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		return time.Local
+	}
+
+	return loc
+}()
+
+//parseServerTimestamp finds and parses the server's timestamp anywhere in a response
+//This returns a zero time if the response doesn't have a timestamp or it couldn't be parsed.
+func parseServerTimestamp(body []byte) (t time.Time) {
+	d := newDecoder(body)
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return
+		}
+
+		start, ok := tok.(xml.StartElement)
+		if !ok || !timestampElements[start.Name.Local] {
+			continue
+		}
+
+		var s string
+		if err := d.DecodeElement(&s, &start); err != nil {
+			return
+		}
+
+		s = strings.TrimSpace(s)
+		for _, layout := range timestampLayouts {
+			if parsed, err := time.ParseInLocation(layout, s, wardLocation); err == nil {
+				return parsed
+			}
+		}
+	}
+}
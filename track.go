@@ -0,0 +1,150 @@
+package ward
+
+import (
+	"context"
+	"encoding/xml"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+//EndpointTracking is the name of the tracking endpoint, used with SetEndpointConfig
+const EndpointTracking = "tracking"
+
+//ErrNoTrackingURL is returned when tracking a shipment before the tracking url is set
+//Ward's documentation for the pickup and rate quote services does not include a tracking service, so the url
+//of the tracking service your Ward account has access to must be set with SetTrackingURL.
+var ErrNoTrackingURL = errors.New("ward - tracking url is not set")
+
+//ErrShipmentNotFound is returned when Ward doesn't have a shipment for the pro number or pickup confirmation
+var ErrShipmentNotFound = errors.New("ward - shipment not found")
+
+//SetTrackingURL sets the url of Ward's tracking service
+//Tracking doesn't create anything so the same url is used in test and production mode.
+func SetTrackingURL(u string) {
+	defaultClient.SetTrackingURL(u)
+	return
+}
+
+//SetTrackingURL sets the url of Ward's tracking service, see SetTrackingURL
+func (c *Client) SetTrackingURL(u string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.trackingURL = u
+	return
+}
+
+//TrackRequest is the shipment to look up
+//Provide either the pro number from the bill of lading or the confirmation number from a pickup request.
+type TrackRequest struct {
+	ProNumber          string `xml:"ProNumber,omitempty"`
+	PickupConfirmation string `xml:"PickupConfirmation,omitempty"`
+}
+
+//TrackResponse is the data returned when looking up a shipment
+type TrackResponse struct {
+	XMLName      xml.Name            `xml:"Envelope" json:"-"`                                    //dont need "soap12"
+	CreateResult TrackResponseResult `xml:"Body>CreateResponse>CreateResult" json:"createResult"` //dont need "soap12"
+
+	Duration time.Duration `xml:"-" json:"duration"` //how long the call to Ward took, including any retries
+}
+
+//TrackResponseResult is the actual body of the tracking response
+type TrackResponseResult struct {
+	ProNumber             string        `xml:"ProNumber" json:"proNumber"`
+	PickupConfirmation    string        `xml:"PickupConfirmation" json:"pickupConfirmation"`
+	Status                string        `xml:"Status" json:"status"` //the most recent status, i.e. picked up, in transit, delivered
+	CurrentLocation       TrackLocation `xml:"CurrentLocation" json:"currentLocation"`
+	EstimatedDeliveryDate string        `xml:"EstimatedDeliveryDate" json:"estimatedDeliveryDate"` //mm/dd/yy
+	DeliveredDate         string        `xml:"DeliveredDate" json:"deliveredDate"`                 //mm/dd/yy, blank until delivered
+	Events                []TrackEvent  `xml:"Events>Event" json:"events"`                         //oldest first
+	Message               string        `xml:"Message" json:"message"`
+
+	Timestamp time.Time `xml:"-" json:"timestamp"` //ward's server time from the response, zero if Ward didn't send one
+}
+
+//TrackLocation is where a shipment is or was
+type TrackLocation struct {
+	ServiceCenter string `xml:"ServiceCenter" json:"serviceCenter"`
+	City          string `xml:"City" json:"city"`
+	State         string `xml:"State" json:"state"` //two char code
+}
+
+//TrackEvent is one status update in a shipment's history
+type TrackEvent struct {
+	Date     string        `xml:"Date" json:"date"` //mm/dd/yy
+	Time     string        `xml:"Time" json:"time"` //hhmm, 24 hour
+	Status   string        `xml:"Status" json:"status"`
+	Location TrackLocation `xml:"Location" json:"location"`
+}
+
+//TrackRequest returns the request to track the shipment scheduled by a pickup request
+func (r PickupRequestResponse) TrackRequest() TrackRequest {
+	return TrackRequest{PickupConfirmation: r.CreateResult.PickupConfirmation}
+}
+
+//TrackShipment looks up the status of a shipment using the default client
+func TrackShipment(t TrackRequest) (responseData TrackResponse, err error) {
+	return defaultClient.TrackShipment(t)
+}
+
+//TrackShipment looks up the status of a shipment by pro number or pickup confirmation
+func (c *Client) TrackShipment(t TrackRequest) (responseData TrackResponse, err error) {
+	//track how long the call takes
+	start := c.now()
+	defer func() {
+		responseData.Duration = c.since(start)
+		c.observe(EndpointTracking, responseData.Duration, err)
+	}()
+
+	c.mu.RLock()
+	endpointURL := c.trackingURL
+	c.mu.RUnlock()
+	if endpointURL == "" {
+		err = ErrNoTrackingURL
+		return
+	}
+
+	t.ProNumber = strings.TrimSpace(t.ProNumber)
+	t.PickupConfirmation = strings.TrimSpace(t.PickupConfirmation)
+	if t.ProNumber == "" && t.PickupConfirmation == "" {
+		err = errors.New("ward.TrackShipment - a pro number or pickup confirmation is required")
+		return
+	}
+
+	//convert the tracking request to an xml
+	xmlString, err := c.buildSOAPBody(t)
+	if err != nil {
+		err = errors.Wrap(err, "ward.TrackShipment - could not marshal xml")
+		return
+	}
+
+	//make the call to the ward API and read the response
+	body, _, err := c.doRequest(context.Background(), EndpointTracking, endpointURL, xmlString, true)
+	if err != nil {
+		err = errors.Wrap(err, "ward.TrackShipment - could not make request")
+		return
+	}
+
+	err = unmarshalResponse(body, &responseData)
+	if err != nil {
+		err = errors.Wrap(err, "ward.TrackShipment - could not read response")
+		return
+	}
+
+	//keep Ward's timestamp for auditing
+	responseData.CreateResult.Timestamp = parseServerTimestamp(body)
+
+	//no status means Ward didn't find the shipment
+	if responseData.CreateResult.Status == "" && len(responseData.CreateResult.Events) == 0 {
+		err = ErrShipmentNotFound
+		if msg := strings.TrimSpace(responseData.CreateResult.Message); msg != "" {
+			err = errors.Wrap(err, msg)
+		}
+		return
+	}
+
+	return
+}
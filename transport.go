@@ -0,0 +1,309 @@
+package ward
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+//names of the Ward endpoints, used to configure how requests are sent to each
+const (
+	EndpointPickup    = "pickup"
+	EndpointRateQuote = "ratequote"
+)
+
+//BodyPlacement is where the xml is put in the http request
+type BodyPlacement int
+
+//where the xml can be put in the http request
+const (
+	BodyRaw       BodyPlacement = iota //the xml is the entire request body
+	BodyFormField                      //the xml is the value of a form field, in the body for POST or the query string for GET
+)
+
+//EndpointConfig is how requests are sent to a Ward endpoint
+//Ward is inconsistent between endpoints so this allows adapting without changing the package.  Any field left
+//blank uses the default.
+type EndpointConfig struct {
+	Method      string        //http method, defaults to POST
+	ContentType string        //defaults to SetContentType or the SOAP version's type for raw bodies, or a standard form type for form fields
+	Body        BodyPlacement //defaults to a raw body
+	FormField   string        //name of the form field holding the xml when Body is BodyFormField, defaults to "xml"
+}
+
+//Content-Type headers for raw xml bodies
+//Ward's documentation doesn't name a Content-Type.  ContentTypeLegacy is what Ward's demo sent (it isn't a real
+//MIME type) and is still sent by default with SOAP 1.2 since it is known to work.  ContentTypeSOAP12 is the
+//correct type for SOAP 1.2 but hasn't been confirmed with Ward, use it with SetContentType to try it.  SOAP 1.1
+//requires ContentTypeSOAP11.
+const (
+	ContentTypeSOAP12 = "application/soap+xml; charset=utf-8"
+	ContentTypeSOAP11 = "text/xml; charset=utf-8"
+	ContentTypeLegacy = "application/x-www-form-encoded"
+)
+
+//defaults for sending requests
+const (
+	defaultMethod          = http.MethodPost
+	defaultFormContentType = "application/x-www-form-urlencoded"
+	defaultFormField       = "xml"
+)
+
+//SetContentType sets the Content-Type header sent with raw xml bodies for every endpoint
+//A blank content type uses the SOAP version's type.  An EndpointConfig's ContentType overrides this.
+func SetContentType(ct string) {
+	defaultClient.SetContentType(ct)
+	return
+}
+
+//SetContentType sets the Content-Type header sent with raw xml bodies, see SetContentType
+func (c *Client) SetContentType(ct string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.contentType = ct
+	return
+}
+
+//SetEndpointConfig sets how requests are sent to one of Ward's endpoints (EndpointPickup, EndpointRateQuote, etc.)
+func SetEndpointConfig(endpoint string, cfg EndpointConfig) {
+	defaultClient.SetEndpointConfig(endpoint, cfg)
+	return
+}
+
+//SetEndpointConfig sets how requests are sent to one of Ward's endpoints (EndpointPickup, EndpointRateQuote, etc.)
+func (c *Client) SetEndpointConfig(endpoint string, cfg EndpointConfig) {
+	c.endpointConfigsMu.Lock()
+	defer c.endpointConfigsMu.Unlock()
+
+	c.endpointConfigs[endpoint] = cfg
+	return
+}
+
+//endpointConfig returns the configuration for an endpoint with any blank fields filled in by defaults
+func (c *Client) endpointConfig(endpoint string) (cfg EndpointConfig) {
+	c.endpointConfigsMu.RLock()
+	cfg = c.endpointConfigs[endpoint]
+	c.endpointConfigsMu.RUnlock()
+
+	if cfg.Method == "" {
+		cfg.Method = defaultMethod
+	}
+	if cfg.FormField == "" {
+		cfg.FormField = defaultFormField
+	}
+	if cfg.ContentType == "" {
+		if cfg.Body == BodyFormField {
+			cfg.ContentType = defaultFormContentType
+		} else {
+			c.mu.RLock()
+			cfg.ContentType = c.contentType
+			if cfg.ContentType == "" {
+				cfg.ContentType = c.soapVersion.contentType()
+			}
+			c.mu.RUnlock()
+		}
+	}
+
+	return
+}
+
+//newRequest builds the http request to send the xml to Ward
+func (cfg EndpointConfig) newRequest(endpointURL, xmlString string, v SOAPVersion) (req *http.Request, err error) {
+	//raw body
+	if cfg.Body != BodyFormField {
+		req, err = http.NewRequest(cfg.Method, endpointURL, strings.NewReader(xmlString))
+		if err != nil {
+			return
+		}
+
+		req.Header.Set("Content-Type", cfg.ContentType)
+		if v == SOAP11 {
+			//SOAP 1.1 requires this header, empty means the action is the url
+			req.Header.Set("SOAPAction", `""`)
+		}
+		return
+	}
+
+	//form field
+	//GET and HEAD requests don't have a body, so put the xml in the query string
+	if cfg.Method == http.MethodGet || cfg.Method == http.MethodHead {
+		u, parseErr := url.Parse(endpointURL)
+		if parseErr != nil {
+			err = parseErr
+			return
+		}
+
+		q := u.Query()
+		q.Set(cfg.FormField, xmlString)
+		u.RawQuery = q.Encode()
+
+		req, err = http.NewRequest(cfg.Method, u.String(), nil)
+		return
+	}
+
+	form := url.Values{}
+	form.Set(cfg.FormField, xmlString)
+
+	req, err = http.NewRequest(cfg.Method, endpointURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return
+	}
+
+	req.Header.Set("Content-Type", cfg.ContentType)
+	return
+}
+
+//doRequest sends the xml to a Ward endpoint and returns the response body, http status code, and headers
+//Failed attempts are retried per SetRetries when retry is true, all within the operation timeout if one is
+//set.  If parent is cancelled the request stops and parent's error is returned.
+func (c *Client) doRequest(parent context.Context, endpoint, endpointURL, xmlString string, retry bool) (body []byte, meta ResponseMeta, err error) {
+	//keep the xml for debugging, even if the request failed
+	start := c.now()
+	defer func() {
+		c.recordXML(xmlString, body)
+		c.debugLog(parent, endpoint, c.since(start), meta.StatusCode, xmlString, err)
+	}()
+
+	c.mu.RLock()
+	operationTimeout, retries, backoff := c.operationTimeout, c.retries, c.retryBackoff
+	c.mu.RUnlock()
+
+	if !retry {
+		retries = 0
+	}
+
+	ctx := parent
+	if operationTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, operationTimeout)
+		defer cancel()
+	}
+
+	cfg := c.endpointConfig(endpoint)
+
+	for attempt := 0; ; attempt++ {
+		body, meta, err = c.doAttempt(ctx, cfg, endpointURL, xmlString)
+		if err == nil {
+			return
+		}
+		if parent.Err() != nil {
+			err = parent.Err()
+			return
+		}
+		if ctx.Err() != nil {
+			err = ErrTimeout
+			return
+		}
+		if attempt >= retries || !IsRetryable(err) {
+			return
+		}
+
+		//wait before retrying, giving up if the operation runs out of time while waiting
+		t := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			err = ErrTimeout
+			if parent.Err() != nil {
+				err = parent.Err()
+			}
+			return
+		case <-t.C:
+		}
+
+		backoff *= 2
+	}
+}
+
+//doAttempt makes one attempt at sending the xml to Ward and reading the response
+func (c *Client) doAttempt(ctx context.Context, cfg EndpointConfig, endpointURL, xmlString string) (body []byte, meta ResponseMeta, err error) {
+	req, err := cfg.newRequest(endpointURL, xmlString, c.getSOAPVersion())
+	if err != nil {
+		return
+	}
+
+	//ask for a compressed response, readResponseBody decompresses it
+	//setting this ourselves stops the http transport from decompressing so it works with any http client
+	req.Header.Set("Accept-Encoding", "gzip")
+
+	//add any headers the caller set, replacing the headers above
+	c.addHeaders(ctx, req)
+
+	err = c.authenticate(req)
+	if err != nil {
+		return
+	}
+
+	res, err := c.getHTTPClient().Do(req.WithContext(ctx))
+	if err != nil {
+		return
+	}
+
+	meta = ResponseMeta{StatusCode: res.StatusCode, Header: res.Header}
+	body, err = readResponseBody(res)
+	if err != nil {
+		return
+	}
+
+	//a fault is Ward's explanation of a failure, SOAP 1.2 faults are usually sent with a 500 status
+	if fault := parseSOAPFault(body, meta.StatusCode); fault != nil {
+		err = fault
+		return
+	}
+
+	//check the status before the body is parsed so an error page isn't mistaken for a bad request
+	if meta.StatusCode < 200 || meta.StatusCode > 299 {
+		err = &HTTPError{StatusCode: meta.StatusCode, Body: body}
+		return
+	}
+
+	//an empty body would otherwise fail to unmarshal, or look like Ward rejected the request
+	if len(bytes.TrimSpace(stripBOM(body))) == 0 {
+		err = ErrEmptyResponse
+		return
+	}
+
+	return
+}
+
+//SetHTTPClient sets the http client used to connect to Ward
+//Use this to set a proxy, tls config, or a transport that reuses connections across many requests.  If the
+//client doesn't have a timeout the timeout set with SetTimeout is used.  Pass nil to go back to the default.
+func SetHTTPClient(hc *http.Client) {
+	defaultClient.SetHTTPClient(hc)
+	return
+}
+
+//SetHTTPClient sets the http client used to connect to Ward, see SetHTTPClient
+func (c *Client) SetHTTPClient(hc *http.Client) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.httpClient = hc
+	return
+}
+
+//getHTTPClient returns the http client to connect to Ward with
+//set a timeout since golang doesn't set one by default and we don't want this to hang forever
+func (c *Client) getHTTPClient() *http.Client {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.httpClient == nil {
+		return &http.Client{
+			Timeout: c.timeout,
+		}
+	}
+
+	if c.httpClient.Timeout == 0 {
+		hc := *c.httpClient
+		hc.Timeout = c.timeout
+		return &hc
+	}
+
+	return c.httpClient
+}
@@ -0,0 +1,254 @@
+package ward
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+//contentTypeOf returns a handler that records the Content-Type of each request and responds with body
+func contentTypeOf(ct *string, body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		*ct = r.Header.Get("Content-Type")
+		w.Write([]byte(body))
+	}
+}
+
+func TestContentType(t *testing.T) {
+	tests := []struct {
+		name     string
+		setup    func(c *Client)
+		expected string
+	}{
+		{"default", func(c *Client) {}, ContentTypeLegacy},
+		{"soap 1.2", func(c *Client) { c.SetContentType(ContentTypeSOAP12) }, ContentTypeSOAP12},
+		{"soap 1.1", func(c *Client) { c.SetSOAPVersion(SOAP11) }, ContentTypeSOAP11},
+		{"endpoint config", func(c *Client) {
+			c.SetContentType(ContentTypeSOAP12)
+			c.SetEndpointConfig(EndpointRateQuote, EndpointConfig{ContentType: ContentTypeLegacy})
+		}, ContentTypeLegacy},
+	}
+
+	for _, tt := range tests {
+		var ct string
+		c, _ := newTestClient(t, contentTypeOf(&ct, quoteSuccessXML))
+		tt.setup(c)
+
+		q := testRateQuoteRequest()
+		if _, err := c.RateQuote(&q); err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+		if ct != tt.expected {
+			t.Errorf("%s: expected Content-Type %q, got %q", tt.name, tt.expected, ct)
+		}
+	}
+}
+
+//sentRequest is what a stub server received
+type sentRequest struct {
+	method      string
+	contentType string
+	soapAction  []string
+	query       url.Values
+	body        string
+}
+
+//received returns a handler that records each request and responds with body
+func received(sent *sentRequest, body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		*sent = sentRequest{
+			method:      r.Method,
+			contentType: r.Header.Get("Content-Type"),
+			soapAction:  r.Header.Values("SOAPAction"),
+			query:       r.URL.Query(),
+			body:        string(b),
+		}
+		w.Write([]byte(body))
+	}
+}
+
+func TestEndpointConfigRequests(t *testing.T) {
+	const formType = "application/x-www-form-urlencoded"
+
+	tests := []struct {
+		name        string
+		cfg         EndpointConfig
+		soap11      bool
+		method      string
+		contentType string
+		place       string //where the xml should be, body, form, or query
+		field       string
+	}{
+		{"raw default", EndpointConfig{}, false, http.MethodPost, ContentTypeLegacy, "body", ""},
+		{"raw soap 1.1", EndpointConfig{}, true, http.MethodPost, ContentTypeSOAP11, "body", ""},
+		{"raw content type", EndpointConfig{ContentType: ContentTypeSOAP12}, false, http.MethodPost, ContentTypeSOAP12, "body", ""},
+		{"raw put", EndpointConfig{Method: http.MethodPut}, false, http.MethodPut, ContentTypeLegacy, "body", ""},
+		{"raw get", EndpointConfig{Method: http.MethodGet}, false, http.MethodGet, ContentTypeLegacy, "body", ""},
+		{"form post", EndpointConfig{Body: BodyFormField}, false, http.MethodPost, formType, "form", "xml"},
+		{"form post soap 1.1", EndpointConfig{Body: BodyFormField}, true, http.MethodPost, formType, "form", "xml"},
+		{"form post field", EndpointConfig{Body: BodyFormField, FormField: "request"}, false, http.MethodPost, formType, "form", "request"},
+		{"form post content type", EndpointConfig{Body: BodyFormField, ContentType: formType + "; charset=utf-8"}, false, http.MethodPost, formType + "; charset=utf-8", "form", "xml"},
+		{"form put", EndpointConfig{Method: http.MethodPut, Body: BodyFormField}, false, http.MethodPut, formType, "form", "xml"},
+		{"form get", EndpointConfig{Method: http.MethodGet, Body: BodyFormField}, false, http.MethodGet, "", "query", "xml"},
+		{"form get field", EndpointConfig{Method: http.MethodGet, Body: BodyFormField, FormField: "request"}, false, http.MethodGet, "", "query", "request"},
+		{"form get soap 1.1", EndpointConfig{Method: http.MethodGet, Body: BodyFormField}, true, http.MethodGet, "", "query", "xml"},
+	}
+
+	for _, tt := range tests {
+		var sent sentRequest
+		c, srv := newTestClient(t, received(&sent, quoteSuccessXML))
+		c.SetRateQuoteURLs(srv.URL+"/quote?key=abc", srv.URL+"/quote?key=abc")
+		c.SetEndpointConfig(EndpointRateQuote, tt.cfg)
+		if tt.soap11 {
+			c.SetSOAPVersion(SOAP11)
+		}
+
+		q := testRateQuoteRequest()
+		if _, err := c.RateQuote(&q); err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+			continue
+		}
+
+		if sent.method != tt.method {
+			t.Errorf("%s: expected method %s, got %s", tt.name, tt.method, sent.method)
+		}
+		if sent.contentType != tt.contentType {
+			t.Errorf("%s: expected Content-Type %q, got %q", tt.name, tt.contentType, sent.contentType)
+		}
+
+		//only raw SOAP 1.1 bodies have a SOAPAction
+		if tt.soap11 && tt.place == "body" {
+			if len(sent.soapAction) != 1 || sent.soapAction[0] != `""` {
+				t.Errorf("%s: expected an empty quoted SOAPAction, got %q", tt.name, sent.soapAction)
+			}
+		} else if len(sent.soapAction) != 0 {
+			t.Errorf("%s: expected no SOAPAction, got %q", tt.name, sent.soapAction)
+		}
+
+		//the url's own query is kept
+		if sent.query.Get("key") != "abc" {
+			t.Errorf("%s: expected the url's query to be kept, got %v", tt.name, sent.query)
+		}
+
+		var xmlString string
+		switch tt.place {
+		case "body":
+			xmlString = sent.body
+		case "form":
+			form, err := url.ParseQuery(sent.body)
+			if err != nil {
+				t.Errorf("%s: could not parse form body %q: %v", tt.name, sent.body, err)
+				continue
+			}
+			if len(form) != 1 {
+				t.Errorf("%s: expected only the %s field, got %v", tt.name, tt.field, form)
+			}
+			xmlString = form.Get(tt.field)
+		case "query":
+			if sent.body != "" {
+				t.Errorf("%s: expected no body, got %q", tt.name, sent.body)
+			}
+			xmlString = sent.query.Get(tt.field)
+		}
+
+		if !strings.HasPrefix(xmlString, "<?xml") || !strings.Contains(xmlString, "<Customer>12345</Customer>") {
+			t.Errorf("%s: expected the xml in the %s, got %q", tt.name, tt.place, xmlString)
+		}
+	}
+}
+
+func TestNewRequestFormHead(t *testing.T) {
+	cfg := EndpointConfig{Method: http.MethodHead, Body: BodyFormField, FormField: "xml", ContentType: defaultFormContentType}
+
+	req, err := cfg.newRequest("https://example.com/quote?key=abc", "<a>1 & 2</a>", SOAP12)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if req.Method != http.MethodHead || req.Body != nil {
+		t.Fatalf("expected a HEAD request without a body, got %s with %v", req.Method, req.Body)
+	}
+	if q := req.URL.Query(); q.Get("xml") != "<a>1 & 2</a>" || q.Get("key") != "abc" {
+		t.Fatalf("expected the escaped xml in the query string, got %s", req.URL.RawQuery)
+	}
+
+	//a url that can't be parsed is an error rather than a request without the xml
+	cfg.Method = http.MethodGet
+	if _, err := cfg.newRequest("http://[::1", "<a/>", SOAP12); err == nil {
+		t.Fatal("expected an error for a malformed url")
+	}
+}
+
+//gzipped compresses body
+func gzipped(t *testing.T, body string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write([]byte(body)); err != nil {
+		t.Fatalf("could not compress: %v", err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("could not compress: %v", err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestGzipResponse(t *testing.T) {
+	compressed := gzipped(t, quoteSuccessXML)
+
+	var acceptEncoding string
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		acceptEncoding = r.Header.Get("Accept-Encoding")
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Write(compressed)
+	})
+
+	q := testRateQuoteRequest()
+	res, err := c.RateQuote(&q)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if acceptEncoding != "gzip" {
+		t.Fatalf("expected Accept-Encoding gzip, got %q", acceptEncoding)
+	}
+	if res.CreateResult.QuoteID != "Q98765" {
+		t.Fatalf("unexpected response %+v", res.CreateResult)
+	}
+}
+
+func TestGzipResponseTruncated(t *testing.T) {
+	compressed := gzipped(t, quoteSuccessXML)
+
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Write(compressed[:len(compressed)/2])
+	})
+
+	q := testRateQuoteRequest()
+	if _, err := c.RateQuote(&q); !errors.Is(err, ErrTruncatedResponse) {
+		t.Fatalf("expected ErrTruncatedResponse, got %v", err)
+	}
+}
+
+func TestEmptyResponse(t *testing.T) {
+	for _, body := range []string{"", " \r\n\t "} {
+		c, _ := newTestClient(t, respond(http.StatusOK, body))
+
+		q := testRateQuoteRequest()
+		if _, err := c.RateQuote(&q); !errors.Is(err, ErrEmptyResponse) {
+			t.Errorf("body %q: expected ErrEmptyResponse, got %v", body, err)
+		}
+
+		p := testPickupRequest()
+		if _, err := c.RequestPickup(&p); !errors.Is(err, ErrEmptyResponse) {
+			t.Errorf("body %q: expected ErrEmptyResponse, got %v", body, err)
+		}
+	}
+}
@@ -0,0 +1,357 @@
+package ward
+
+import (
+	"fmt"
+	"strings"
+)
+
+//Issue is a single problem found when validating a request before it is sent to Ward
+type Issue struct {
+	Field   string //the field with the problem, i.e. ShipperInfo.ShipperState
+	Message string
+	Warning bool //true if this is a caution that will not cause Ward to reject the request
+}
+
+//String returns the issue in a human readable format
+func (i Issue) String() string {
+	if i.Warning {
+		return "warning: " + i.Field + " " + i.Message
+	}
+
+	return i.Field + " " + i.Message
+}
+
+//ValidationError is returned when a request fails validation
+//This lists every problem found so that all of them can be fixed at once instead of one round trip at a time.
+type ValidationError struct {
+	Issues []Issue
+}
+
+//Error implements the error interface
+func (v *ValidationError) Error() string {
+	msgs := make([]string, 0, len(v.Issues))
+	for _, i := range v.Issues {
+		msgs = append(msgs, i.String())
+	}
+
+	return "ward - request is invalid: " + strings.Join(msgs, "; ")
+}
+
+//Validate checks a pickup request for missing or malformed data without making any network calls
+//This returns a *ValidationError listing every problem found, or nil if the request looks ok.  Warnings
+//do not cause an error on their own but are included in the error if there are other problems.
+func (p *PickupRequest) Validate() error {
+	return validationError(p.issues())
+}
+
+//validationError returns a *ValidationError if any of the issues are not warnings
+func validationError(issues []Issue) error {
+	for _, i := range issues {
+		if !i.Warning {
+			return &ValidationError{Issues: issues}
+		}
+	}
+
+	return nil
+}
+
+//issues returns every problem found with a pickup request using the default client's settings
+func (p *PickupRequest) issues() []Issue {
+	return p.issuesFor(defaultClient)
+}
+
+//issuesFor returns every problem found with a pickup request using c's clock, service hours, and holidays
+func (p *PickupRequest) issuesFor(c *Client) (issues []Issue) {
+	s := p.ShipperInfo
+	required := []struct {
+		field string
+		value string
+	}{
+		{"ShipperInfo.ShipperCode", s.ShipperCode},
+		{"ShipperInfo.ShipperName", s.ShipperName},
+		{"ShipperInfo.ShipperAddress1", s.ShipperAddress1},
+		{"ShipperInfo.ShipperCity", s.ShipperCity},
+		{"ShipperInfo.ShipperState", s.ShipperState},
+		{"ShipperInfo.ShipperZipcode", s.ShipperZipcode},
+		{"ShipperInfo.ShipperContactName", s.ShipperContactName},
+		{"ShipperInfo.ShipperContactTelephone", s.ShipperContactTelephone},
+		{"ShipperInfo.ShipperReadyTime", s.ShipperReadyTime},
+		{"ShipperInfo.ShipperCloseTime", s.ShipperCloseTime},
+		{"ShipperInfo.PickupDate", s.PickupDate},
+	}
+	for _, r := range required {
+		if strings.TrimSpace(r.value) == "" {
+			issues = append(issues, Issue{Field: r.field, Message: "is required"})
+		}
+	}
+
+	issues = append(issues, addressIssues("ShipperInfo.Shipper", s.ShipperCountry, s.ShipperState, s.ShipperZipcode)...)
+	issues = append(issues, p.Shipment.CheckConsignee()...)
+
+	//telephones are only numbers, optional ones are only checked when given
+	phones := []struct {
+		field string
+		value string
+	}{
+		{"ShipperInfo.ShipperContactTelephone", s.ShipperContactTelephone},
+		{"ShipperInfo.ThirdPartyContactTelephone", s.ThirdPartyContactTelephone},
+		{"ShipperInfo.WardAssuredContactTelephone", s.WardAssuredContactTelephone},
+		{"ShipperInfo.RequestorContactTelephone", s.RequestorContactTelephone},
+		{"Shipment.ConsigneeContactTelephone", p.Shipment.ConsigneeContactTelephone},
+	}
+	for _, ph := range phones {
+		if ph.value != "" && !isTelephone(ph.value) {
+			issues = append(issues, Issue{Field: ph.field, Message: "must be ten digits, only numbers"})
+		}
+	}
+
+	//emails are optional but Ward's notifications silently fail when one is malformed
+	emails := []struct {
+		field string
+		value string
+	}{
+		{"ShipperInfo.ShipperContactEmail", s.ShipperContactEmail},
+		{"ShipperInfo.ThirdPartyContactEmail", s.ThirdPartyContactEmail},
+		{"ShipperInfo.WardAssuredContactEmail", s.WardAssuredContactEmail},
+		{"ShipperInfo.RequestorContactEmail", s.RequestorContactEmail},
+	}
+	for _, e := range emails {
+		if strings.TrimSpace(e.value) != "" && !isEmail(strings.TrimSpace(e.value)) {
+			issues = append(issues, Issue{Field: e.field, Message: "must be an email address, i.e. name@example.com"})
+		}
+	}
+
+	issues = append(issues, s.checkPickupWindow(c.getServiceHours())...)
+	issues = append(issues, s.checkPickupDate(c)...)
+	issues = append(issues, p.Shipment.CheckTimeDefiniteWindow()...)
+	issues = append(issues, p.Shipment.checkDeliveryAppointment(c.now())...)
+	issues = append(issues, p.Shipment.CheckHazmat()...)
+	issues = append(issues, p.Shipment.CheckFullValue()...)
+	issues = append(issues, p.Shipment.CheckFlags()...)
+
+	if p.Shipment.ShipperRoutingSCAC != "" && !ValidSCAC(p.Shipment.ShipperRoutingSCAC) {
+		issues = append(issues, Issue{Field: "Shipment.ShipperRoutingSCAC", Message: "must be two to four uppercase letters"})
+	}
+
+	if p.Shipment.PackageCode != "" && !ValidPackageCode(p.Shipment.PackageCode) {
+		issues = append(issues, Issue{Field: "Shipment.PackageCode", Message: "is not a known package code", Warning: true})
+	}
+
+	if p.Shipment.Pieces == 0 {
+		issues = append(issues, Issue{Field: "Shipment.Pieces", Message: "must be greater than zero"})
+	}
+	if p.Shipment.Weight == 0 {
+		issues = append(issues, Issue{Field: "Shipment.Weight", Message: "must be greater than zero"})
+	}
+	if _, err := p.Shipment.InsuredAmountCents(); err != nil {
+		issues = append(issues, Issue{Field: "Shipment.FullValueInsuredAmount", Message: "must be a dollar amount with at most two decimal places"})
+	}
+	if p.Shipment.DeliveryAppointment == AppointmentRequired && p.Shipment.ConsigneeContactTelephone == "" {
+		issues = append(issues, Issue{Field: "Shipment.ConsigneeContactTelephone", Message: "is required when a delivery appointment is required"})
+	}
+
+	return
+}
+
+//BatchValidationResult is the validation outcome for one request in a batch
+type BatchValidationResult struct {
+	Index  int //index of the request in the slice given to ValidateBatch
+	Issues []Issue
+}
+
+//ValidateBatch validates many pickup requests concurrently without sending any of them to Ward
+//This is useful as a pre-flight check before a large run.  Only requests with problems are returned, in
+//the same order as the input.  A concurrency of zero or less uses one worker per cpu.
+func ValidateBatch(reqs []PickupRequest, concurrency int) (results []BatchValidationResult) {
+	all := make([][]Issue, len(reqs))
+	runPool(len(reqs), concurrency, func(i int) {
+		all[i] = reqs[i].issues()
+	})
+
+	for i, issues := range all {
+		if len(issues) > 0 {
+			results = append(results, BatchValidationResult{Index: i, Issues: issues})
+		}
+	}
+
+	return
+}
+
+//maxPickupWeight is the most weight, in lbs, Ward will typically pick up as LTL freight at one time
+//Anything heavier is usually volume or truckload freight and needs to be arranged with Ward directly.
+const maxPickupWeight = 20000
+
+//ValidateShipments checks this pickup request and any others going out with it for problems across the shipments
+//Ward takes one shipment per pickup request so a pickup with many shipments is sent as many requests, pass the
+//rest of them as others.  This catches batch assembly bugs that per-request validation can't: pickup dates that
+//differ for the same shipper, the same consignee and reference appearing more than once (a shipment appended
+//twice would dispatch duplicate freight), piece counts that can't be right for the weight, and a total weight
+//that is too much for an LTL pickup.  Fields are prefixed with the request's index, this request is [0].
+func (p PickupRequest) ValidateShipments(others ...PickupRequest) (issues []Issue) {
+	reqs := append([]PickupRequest{p}, others...)
+
+	type shipperKey struct {
+		code string
+		zip  string
+	}
+	type shipmentKey struct {
+		shipper   shipperKey
+		consignee string
+		zip       string
+		reference string
+	}
+
+	dates := map[shipperKey]int{}
+	seen := map[shipmentKey]int{}
+	weights := map[shipperKey]uint{}
+
+	for i, r := range reqs {
+		shipper := shipperKey{
+			code: strings.TrimSpace(r.ShipperInfo.ShipperCode),
+			zip:  strings.TrimSpace(r.ShipperInfo.ShipperZipcode),
+		}
+
+		//pickup date should be the same for every shipment from the same shipper
+		if first, ok := dates[shipper]; ok {
+			if reqs[first].ShipperInfo.PickupDate != r.ShipperInfo.PickupDate {
+				issues = append(issues, Issue{
+					Field:   fmt.Sprintf("[%d].ShipperInfo.PickupDate", i),
+					Message: fmt.Sprintf("is %q but request %d from the same shipper is %q", r.ShipperInfo.PickupDate, first, reqs[first].ShipperInfo.PickupDate),
+				})
+			}
+		} else {
+			dates[shipper] = i
+		}
+
+		//the same consignee and reference more than once is most likely a duplicate
+		shipment := shipmentKey{
+			shipper:   shipper,
+			consignee: strings.ToUpper(strings.TrimSpace(r.Shipment.ConsigneeName)),
+			zip:       strings.TrimSpace(r.Shipment.ConsigneeZipcode),
+			reference: strings.TrimSpace(r.Shipment.RequestorReference),
+		}
+		if first, ok := seen[shipment]; ok {
+			issues = append(issues, Issue{
+				Field:   fmt.Sprintf("[%d].Shipment", i),
+				Message: fmt.Sprintf("has the same consignee and reference as request %d", first),
+			})
+		} else {
+			seen[shipment] = i
+		}
+
+		//every piece weighs something, so more pieces than lbs means the pieces or weight were mistyped
+		weight := toPounds(r.Shipment.Weight, r.Shipment.WeightUnit)
+		switch {
+		case r.Shipment.Pieces == 0:
+			issues = append(issues, Issue{Field: fmt.Sprintf("[%d].Shipment.Pieces", i), Message: "must be greater than zero"})
+		case weight > 0 && r.Shipment.Pieces > weight:
+			issues = append(issues, Issue{
+				Field:   fmt.Sprintf("[%d].Shipment.Pieces", i),
+				Message: fmt.Sprintf("is %d pieces for %d lbs, less than a pound each", r.Shipment.Pieces, weight),
+			})
+		}
+
+		weights[shipper] += weight
+	}
+
+	//total weight per shipper, checked in input order so results are consistent
+	for i, r := range reqs {
+		shipper := shipperKey{
+			code: strings.TrimSpace(r.ShipperInfo.ShipperCode),
+			zip:  strings.TrimSpace(r.ShipperInfo.ShipperZipcode),
+		}
+		if dates[shipper] != i {
+			continue
+		}
+
+		if total := weights[shipper]; total > maxPickupWeight {
+			issues = append(issues, Issue{
+				Field:   fmt.Sprintf("[%d].Shipment.Weight", i),
+				Message: fmt.Sprintf("total weight of %d lbs for this shipper is more than a typical LTL pickup of %d lbs", total, maxPickupWeight),
+				Warning: true,
+			})
+		}
+	}
+
+	return
+}
+
+//isStateCode checks if a state is a two letter code
+func isStateCode(s string) bool {
+	if len(s) != 2 {
+		return false
+	}
+
+	for _, r := range s {
+		if (r < 'A' || r > 'Z') && (r < 'a' || r > 'z') {
+			return false
+		}
+	}
+
+	return true
+}
+
+//isTelephone checks if a telephone number is exactly ten digits
+func isTelephone(s string) bool {
+	if len(s) != 10 {
+		return false
+	}
+
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+//Validate checks a rate quote request for missing or malformed data without making any network calls
+//This returns a *ValidationError listing every problem found, or nil if the request looks ok.
+func (r *RateQuoteRequest) Validate() error {
+	return validationError(r.issues())
+}
+
+//issues returns every problem found with a rate quote request using the default client's settings
+func (r *RateQuoteRequest) issues() []Issue {
+	return r.issuesFor(defaultClient)
+}
+
+//issuesFor returns every problem found with a rate quote request using c's settings
+func (r *RateQuoteRequest) issuesFor(c *Client) (issues []Issue) {
+	q := r.Request
+
+	issues = append(issues, addressIssues("Request.Origin", q.OriginCountry, q.OriginState, q.OriginZipcode)...)
+	issues = append(issues, addressIssues("Request.Destination", q.DestinationCountry, q.DestinationState, q.DestinationZipcode)...)
+
+	if strings.TrimSpace(q.OriginZipcode) == "" {
+		issues = append(issues, Issue{Field: "Request.OriginZipcode", Message: "is required"})
+	}
+	if strings.TrimSpace(q.DestinationZipcode) == "" {
+		issues = append(issues, Issue{Field: "Request.DestinationZipcode", Message: "is required"})
+	}
+
+	if !ValidBillingTerms(q.BillingTerms) {
+		issues = append(issues, Issue{Field: "Request.BillingTerms", Message: "must be prepaid (P), collect (C), or third party (T)"})
+	}
+
+	if len(q.Details) == 0 {
+		issues = append(issues, Issue{Field: "Request.Details", Message: "must have at least one item"})
+	}
+	for i, d := range q.Details {
+		if d.Pieces == 0 {
+			issues = append(issues, Issue{Field: fmt.Sprintf("Request.Details[%d].Pieces", i), Message: "must be greater than zero"})
+		}
+	}
+
+	//the appointment accessorial is only added when allowed, make sure a missing appointment charge isn't a surprise
+	if q.DeliveryAppointment == AppointmentRequired && !c.accessorialAllowed(AccessorialAppointment) ||
+		q.DeliveryAppointment == AppointmentRequested && !c.accessorialAllowed(AccessorialNotify) {
+		issues = append(issues, Issue{Field: "Request.DeliveryAppointment", Message: "is not sent to Ward since the appointment accessorial code is unverified, see SetAllowUnverifiedAccessorials", Warning: true})
+	}
+
+	issues = append(issues, r.checkPalletCount(c.getStrictPalletCount())...)
+	issues = append(issues, r.CheckAccessorials()...)
+	issues = append(issues, r.checkClasses(c.getClassResolver())...)
+	return
+}
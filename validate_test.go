@@ -0,0 +1,98 @@
+package ward
+
+import "testing"
+
+func TestValidateBatch(t *testing.T) {
+	reqs := make([]PickupRequest, 500)
+	for i := range reqs {
+		reqs[i] = testPickupRequest()
+
+		//every seventh request is missing its shipper name
+		if i%7 == 0 {
+			reqs[i].ShipperInfo.ShipperName = ""
+		}
+	}
+
+	for _, concurrency := range []int{0, 1, 4, 1000} {
+		results := ValidateBatch(reqs, concurrency)
+
+		if len(results) != (len(reqs)+6)/7 {
+			t.Fatalf("concurrency %d: got %d results, want %d", concurrency, len(results), (len(reqs)+6)/7)
+		}
+		for n, r := range results {
+			if r.Index != n*7 {
+				t.Fatalf("concurrency %d: result %d has index %d, want %d", concurrency, n, r.Index, n*7)
+			}
+			if len(r.Issues) != 1 || r.Issues[0].Field != "ShipperInfo.ShipperName" {
+				t.Fatalf("concurrency %d: result %d issues = %v", concurrency, n, r.Issues)
+			}
+		}
+	}
+}
+
+func TestValidateBatchEmpty(t *testing.T) {
+	if results := ValidateBatch(nil, 4); len(results) != 0 {
+		t.Fatalf("got %v, want no results", results)
+	}
+}
+
+func TestValidateShipments(t *testing.T) {
+	a := testPickupRequest()
+	a.Shipment.RequestorReference = "PO1"
+
+	//a different consignee from the same shipper on the same day is fine
+	b := testPickupRequest()
+	b.Shipment.ConsigneeName = "GAMMA FOODS"
+	b.Shipment.RequestorReference = "PO2"
+	if issues := a.ValidateShipments(b); len(issues) != 0 {
+		t.Fatalf("got %v, want no issues", issues)
+	}
+
+	//the same shipment twice
+	if issues := a.ValidateShipments(a); len(issues) != 1 || issues[0].Field != "[1].Shipment" {
+		t.Fatalf("duplicate: got %v", issues)
+	}
+
+	//a different pickup date from the same shipper
+	c := b
+	c.ShipperInfo.PickupDate = "01022099"
+	if issues := a.ValidateShipments(c); len(issues) != 1 || issues[0].Field != "[1].ShipperInfo.PickupDate" {
+		t.Fatalf("pickup date: got %v", issues)
+	}
+
+	//too much weight across shipments is a warning
+	heavy := b
+	heavy.Shipment.Weight = maxPickupWeight
+	issues := a.ValidateShipments(heavy)
+	if len(issues) != 1 || issues[0].Field != "[0].Shipment.Weight" || !issues[0].Warning {
+		t.Fatalf("weight: got %v", issues)
+	}
+}
+
+func TestValidateShipmentsPieces(t *testing.T) {
+	tests := []struct {
+		name   string
+		pieces uint
+		weight uint
+		unit   WeightUnit
+		issue  bool
+	}{
+		{"ok", 2, 1200, Pounds, false},
+		{"one pound each", 50, 50, Pounds, false},
+		{"zero pieces", 0, 1200, Pounds, true},
+		{"more pieces than lbs", 500, 100, Pounds, true},
+		{"kg converted first", 150, 100, Kilograms, false},
+	}
+
+	for _, tt := range tests {
+		p := testPickupRequest()
+		p.Shipment.Pieces = tt.pieces
+		p.Shipment.Weight = tt.weight
+		p.Shipment.WeightUnit = tt.unit
+
+		issues := p.ValidateShipments()
+		if got := len(issues) == 1 && issues[0].Field == "[0].Shipment.Pieces"; got != tt.issue || (!tt.issue && len(issues) != 0) {
+			t.Errorf("%s: got %v", tt.name, issues)
+		}
+	}
+}
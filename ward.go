@@ -5,8 +5,10 @@ documentation.  This uses and XML SOAP API.
 You will need to have a Ward account and register for access to use this.
 
 Currently this package can perform:
-- pickup requests
+- pickup requests and cancellations
 - rate quotes
+- shipment tracking
+- bills of lading
 
 To create a pickup request:
 - Set test or production mode (SetProductionMode()).
@@ -22,13 +24,20 @@ To get a rate quote:
 - Create the rate quote request (RateQuoteRequest{}).
 - Request the rate quote (RateQuote()).
 - Check for any errors.
+
+Settings such as test or production mode and the timeout are kept on a Client.  The package level functions
+use a default client.  To use different settings at the same time, i.e. test and production, create clients
+with NewClient() and use the client's RequestPickup() and RateQuote() methods.
 */
 package ward
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
 	"encoding/xml"
+	"io"
 	"io/ioutil"
-	"log"
 	"net/http"
 	"strings"
 	"time"
@@ -41,37 +50,39 @@ const (
 	pickupRequestTestURL       = "http://208.51.75.23:6082/cgi-bin/map/PICKUPTEST"
 	pickupRequestProductionURL = "http://208.51.75.23:6082/cgi-bin/map/PICKUP"
 
-	rateQuoteURL = "http://208.51.75.23:6082/cgi-bin/map/RATEQUOTE"
+	//Ward doesn't have a separate test endpoint for rate quotes, quotes don't create anything
+	rateQuoteTestURL       = "http://208.51.75.23:6082/cgi-bin/map/RATEQUOTE"
+	rateQuoteProductionURL = "http://208.51.75.23:6082/cgi-bin/map/RATEQUOTE"
 )
 
-//pickupRequestURL is set to the test URL by default
-//This is changed to the production URL when the SetProductionMode function is called
-//Forcing the developer to call the SetProductionMode function ensures the production URL is only used
-//when actually needed.
-var pickupRequestURL = pickupRequestTestURL
-
-//timeout is the default time we should wait for a reply from Ward
-//You may need to adjust this based on how slow connecting to Ward is for you.
-//10 seconds is overly long, but sometimes Ward is very slow.
-var timeout = time.Duration(10 * time.Second)
-
 //base XML data
 var (
 	xsiAttr    = "http://www.w3.org/2001/XMLSchema-instance"
 	xsdAttr    = "http://www.w3.org/2001/XMLSchema"
 	soap12Attr = "http://www.w3.org/2003/05/soap-envelope"
+	soap11Attr = "http://schemas.xmlsoap.org/soap/envelope/"
 )
 
-//SetProductionMode chooses the production url for use
+//ErrTruncatedResponse is returned when the connection to Ward drops before the entire response was read.
+//This is a transient error, not a problem with the request, so the request can be retried.
+var ErrTruncatedResponse = errors.New("ward - response was truncated")
+
+//ErrEmptyResponse is returned when Ward responds successfully but without a body, or with only whitespace
+//This usually means the connection dropped, so like ErrTruncatedResponse the request can be retried.
+var ErrEmptyResponse = errors.New("ward - empty response body")
+
+//SetProductionMode chooses the production urls for use when yes is true, or the test urls when false
+//The test urls are used by default.  Forcing the developer to call the SetProductionMode function ensures the
+//production urls are only used when actually needed.
 func SetProductionMode(yes bool) {
-	pickupRequestURL = pickupRequestProductionURL
+	defaultClient.SetProductionMode(yes)
 	return
 }
 
-//SetTimeout updates the timeout value to something the user sets
+//SetTimeout updates the timeout value to something the user sets, i.e. 30 * time.Second
 //use this to increase the timeout if connecting to Ward is really slow
-func SetTimeout(seconds time.Duration) {
-	timeout = time.Duration(seconds * time.Second)
+func SetTimeout(d time.Duration) {
+	defaultClient.SetTimeout(d)
 	return
 }
 
@@ -79,158 +90,248 @@ func SetTimeout(seconds time.Duration) {
 type PickupRequest struct {
 	XMLName xml.Name `xml:"soap12:Envelope"`
 
+	//the envelope attributes are set based on the SOAP version when marshalled, see SetSOAPVersion
+	//these fields are no longer used and are kept for compatibility
 	XsiAttr    string `xml:"xmlns:xsi,attr"`    //http://www.w3.org/2001/XMLSchema-instance
 	XsdAttr    string `xml:"xmlns:xsd,attr"`    //http://www.w3.org/2001/XMLSchema
 	Soap12Attr string `xml:"xmlns:soap12,attr"` //http://www.w3.org/2003/05/soap-envelope
 
 	ShipperInfo PickupRequestShipperInformation `xml:"soap12:Body>request>ShipperInformation"`
 	Shipment    PickupRequestShipment           `xml:"soap12:Body>request>Shipment"`
+
+	//IdempotencyKey is your unique id for this pickup, i.e. an order number
+	//A request with a key that already scheduled a pickup returns the earlier response instead of scheduling
+	//another pickup, see SetPickupCache.  Pickups are only retried, see SetRetries, when they have a key.  This
+	//isn't sent to Ward.
+	IdempotencyKey string `xml:"-"`
+
+	//Mode chooses the test or production url for just this request, the client's mode is used by default
+	Mode Mode `xml:"-"`
 }
 
 //PickupRequestShipperInformation is our ship from address
 type PickupRequestShipperInformation struct {
-	ShipperCode                 string //ward account number
-	ShipperName                 string //company name
-	ShipperAddress1             string
-	ShipperAddress2             string
-	ShipperCity                 string
-	ShipperState                string //xx
-	ShipperZipcode              string
-	ShipperContactName          string
-	ShipperContactTelephone     string //xxxxxxxxxx, only numbers
-	ShipperContactEmail         string
-	ShipperReadyTime            string //hhmm, 24 hour
-	ShipperCloseTime            string //hhmm, 24 hour
-	PickupDate                  string //mmddyyyy
-	ThirdParty                  string
-	ThirdPartyName              string
-	ThirdPartyContactName       string
-	ThirdPartyContactTelephone  string
-	ThirdPartyContactEmail      string
-	WardAssuredContactName      string
-	WardAssuredContactTelephone string
-	WardAssuredContactEmail     string
-	ShipperRestriction          string
-	DriverNote1                 string
-	DriverNote2                 string
-	DriverNote3                 string
-	RequestOrigin               string //who is making the pickup request
-	RequestorUser               string
-	RequestorRole               string
-	RequestorContactName        string
-	RequestorContactTelephone   string
-	RequestorContactEmail       string
+	ShipperCode                 string `xml:"ShipperCode"` //ward account number
+	ShipperName                 string `xml:"ShipperName"` //company name
+	ShipperAddress1             string `xml:"ShipperAddress1"`
+	ShipperAddress2             string `xml:"ShipperAddress2"`
+	ShipperCity                 string `xml:"ShipperCity"`
+	ShipperState                string `xml:"ShipperState"` //xx
+	ShipperZipcode              string `xml:"ShipperZipcode"`
+	ShipperCountry              string `xml:"ShipperCountry,omitempty"` //US or CA, blank is US
+	ShipperContactName          string `xml:"ShipperContactName"`
+	ShipperContactTelephone     string `xml:"ShipperContactTelephone"` //xxxxxxxxxx, only numbers
+	ShipperContactEmail         string `xml:"ShipperContactEmail"`
+	ShipperReadyTime            string `xml:"ShipperReadyTime"` //hhmm, 24 hour
+	ShipperCloseTime            string `xml:"ShipperCloseTime"` //hhmm, 24 hour
+	PickupDate                  string `xml:"PickupDate"`       //mmddyyyy
+	ThirdParty                  string `xml:"ThirdParty"`
+	ThirdPartyName              string `xml:"ThirdPartyName"`
+	ThirdPartyContactName       string `xml:"ThirdPartyContactName"`
+	ThirdPartyContactTelephone  string `xml:"ThirdPartyContactTelephone"`
+	ThirdPartyContactEmail      string `xml:"ThirdPartyContactEmail"`
+	WardAssuredContactName      string `xml:"WardAssuredContactName"`
+	WardAssuredContactTelephone string `xml:"WardAssuredContactTelephone"`
+	WardAssuredContactEmail     string `xml:"WardAssuredContactEmail"`
+	ShipperRestriction          string `xml:"ShipperRestriction"`
+	DriverNote1                 string `xml:"DriverNote1"`
+	DriverNote2                 string `xml:"DriverNote2"`
+	DriverNote3                 string `xml:"DriverNote3"`
+	RequestOrigin               string `xml:"RequestOrigin"` //who is making the pickup request
+	RequestorUser               string `xml:"RequestorUser"`
+	RequestorRole               string `xml:"RequestorRole"`
+	RequestorContactName        string `xml:"RequestorContactName"`
+	RequestorContactTelephone   string `xml:"RequestorContactTelephone"`
+	RequestorContactEmail       string `xml:"RequestorContactEmail"`
 }
 
 //PickupRequestShipment is the data on the shipment we are requesting a pickup for
 type PickupRequestShipment struct {
-	Pieces                       uint
-	PackageCode                  string //code per Ward's website
-	Weight                       uint   //lbs
-	ConsigneeCode                string
-	ConsigneeName                string
-	ConsigneeAddress1            string
-	ConsigneeAddress2            string
-	ConsigneeCity                string
-	ConsigneeState               string
-	ConsigneeZipcode             string
-	ShipperRoutingSCAC           string
-	Hazardous                    string //Y or N
-	Freezable                    string //Y or N
-	DeliveryAppntFlag            string //Y or N
-	DeliveryAppntDate            string
-	WardAssured12PM              string
-	WardAssured03PM              string
-	WardAssuredTimeDefinite      string
-	WardAssuredTimeDefiniteStart string
-	WardAssuredTimeDefiniteEnd   string
-	FullValue                    string
-	FullValueInsuredAmount       string
-	NonStandardSize              string
-	NonStandardSizeDescription   string
-	RequestorReference           string
-	PickupShipmentInstruction1   string
-	PickupShipmentInstruction2   string
-	PickupShipmentInstruction3   string
-	PickupShipmentInstruction4   string
-	RequestOrigin                string
+	Pieces                       uint   `xml:"Pieces"`
+	PackageCode                  string `xml:"PackageCode"` //code per Ward's website, see the Package constants (unverified)
+	Weight                       uint   `xml:"Weight"`      //lbs, unless WeightUnit is set
+	ConsigneeCode                string `xml:"ConsigneeCode"`
+	ConsigneeName                string `xml:"ConsigneeName"`
+	ConsigneeAddress1            string `xml:"ConsigneeAddress1"`
+	ConsigneeAddress2            string `xml:"ConsigneeAddress2"`
+	ConsigneeCity                string `xml:"ConsigneeCity"`
+	ConsigneeState               string `xml:"ConsigneeState"`
+	ConsigneeZipcode             string `xml:"ConsigneeZipcode"`
+	ConsigneeCountry             string `xml:"ConsigneeCountry,omitempty"` //US or CA, blank is US
+	ShipperRoutingSCAC           string `xml:"ShipperRoutingSCAC"`
+	Hazardous                    string `xml:"Hazardous"`               //Y or N
+	Freezable                    string `xml:"Freezable"`               //Y or N
+	DeliveryAppntFlag            string `xml:"DeliveryAppntFlag"`       //Y or N, set automatically from DeliveryAppointment
+	DeliveryAppntDate            string `xml:"DeliveryAppntDate"`       //mmddyyyy, required when DeliveryAppntFlag is Y
+	WardAssured12PM              string `xml:"WardAssured12PM"`         //Y or N
+	WardAssured03PM              string `xml:"WardAssured03PM"`         //Y or N
+	WardAssuredTimeDefinite      string `xml:"WardAssuredTimeDefinite"` //Y or N
+	WardAssuredTimeDefiniteStart string `xml:"WardAssuredTimeDefiniteStart"`
+	WardAssuredTimeDefiniteEnd   string `xml:"WardAssuredTimeDefiniteEnd"`
+	FullValue                    string `xml:"FullValue"`              //Y or N
+	FullValueInsuredAmount       string `xml:"FullValueInsuredAmount"` //dollar amount, sent as 1000.00
+	NonStandardSize              string `xml:"NonStandardSize"`        //Y or N
+	NonStandardSizeDescription   string `xml:"NonStandardSizeDescription"`
+	RequestorReference           string `xml:"RequestorReference"`
+	PickupShipmentInstruction1   string `xml:"PickupShipmentInstruction1"`
+	PickupShipmentInstruction2   string `xml:"PickupShipmentInstruction2"`
+	PickupShipmentInstruction3   string `xml:"PickupShipmentInstruction3"`
+	PickupShipmentInstruction4   string `xml:"PickupShipmentInstruction4"`
+	RequestOrigin                string `xml:"RequestOrigin"`
+
+	ConsigneeContactTelephone string        `xml:"ConsigneeContactTelephone,omitempty"` //xxxxxxxxxx, only numbers, required for appointment required deliveries
+	Hazmat                    *HazmatDetail `xml:"HazmatDetail,omitempty"`              //required when Hazardous is Y, only sent then
+	DeliveryAppointment       Appointment   `xml:"-"`                                   //none, requested, or required
+	WeightUnit                WeightUnit    `xml:"-"`                                   //the unit of Weight, converted to lbs when sent
 }
 
 //PickupRequestResponse is the data we get back when a pickup is scheduled successfully
 type PickupRequestResponse struct {
-	XMLName      xml.Name                    `xml:"Envelope"`                         //dont need "soap12"
-	CreateResult PickupRequestResponseResult `xml:"Body>CreateResponse>CreateResult"` //dont need "soap12"
+	XMLName      xml.Name                    `xml:"Envelope" json:"-"`                                    //dont need "soap12"
+	CreateResult PickupRequestResponseResult `xml:"Body>CreateResponse>CreateResult" json:"createResult"` //dont need "soap12"
+
+	Duration time.Duration `xml:"-" json:"duration"` //how long the call to Ward took, including any retries
 }
 
 //PickupRequestResponseResult is the actual body of the pickup request response
 type PickupRequestResponseResult struct {
-	PickupConfirmation string //the pickup request confirmation number
-	Message            string
-	PickupTerminal     string
-	WardTelephone      string
-	WardEmail          string
+	PickupConfirmation string `xml:"PickupConfirmation" json:"pickupConfirmation"` //the pickup request confirmation number
+	Message            string `xml:"Message" json:"message"`
+	PickupTerminal     string `xml:"PickupTerminal" json:"pickupTerminal"`
+	WardTelephone      string `xml:"WardTelephone" json:"wardTelephone"`
+	WardEmail          string `xml:"WardEmail" json:"wardEmail"`
+	RequestorReference string `xml:"RequestorReference" json:"requestorReference"` //your reference as Ward echoed it, blank if Ward didn't
+
+	Timestamp time.Time `xml:"-" json:"timestamp"` //ward's server time from the response, zero if Ward didn't send one
+	Warning   string    `xml:"-" json:"warning"`   //the Message when a pickup was scheduled but Ward noted a caveat, plus any reference mismatch
 }
 
-//RequestPickup performs the call to the Ward API to schedule a pickup
-func (p *PickupRequest) RequestPickup() (responseData PickupRequestResponse, err error) {
-	//add xml attributes
-	p.XsdAttr = xsdAttr
-	p.XsiAttr = xsiAttr
-	p.Soap12Attr = soap12Attr
+//ReferenceMatches checks that the RequestorReference Ward echoed matches the reference that was sent
+//Ward's documentation doesn't say if the reference is echoed, so a blank echo is treated as a match since
+//there is nothing to compare.  References are compared ignoring surrounding spaces.
+func (r PickupRequestResponseResult) ReferenceMatches(sent string) bool {
+	echoed := strings.TrimSpace(r.RequestorReference)
+	return echoed == "" || echoed == strings.TrimSpace(sent)
+}
 
-	//convert the pickup request to an xml
-	xmlBytes, err := xml.Marshal(p)
-	if err != nil {
-		err = errors.Wrap(err, "ward.RequestPickup - could not marshal xml")
-		return
+//Warnings returns each caveat Ward noted about a scheduled pickup, nil if the pickup was scheduled as requested
+//Ward sometimes puts more than one caveat in the message separated by semicolons or new lines.
+func (r PickupRequestResponse) Warnings() (warnings []string) {
+	split := func(c rune) bool {
+		return c == ';' || c == '\n' || c == '\r'
 	}
 
-	//add the xml header and an ending blank line
-	//need both to get request to work for some reason
-	xmlString := xml.Header + string(xmlBytes) + "\n"
+	for _, w := range strings.FieldsFunc(r.CreateResult.Warning, split) {
+		if w = strings.TrimSpace(w); w != "" {
+			warnings = append(warnings, w)
+		}
+	}
+
+	return
+}
+
+//RequestPickup performs the call to the Ward API to schedule a pickup using the default client
+func (p *PickupRequest) RequestPickup() (responseData PickupRequestResponse, err error) {
+	return defaultClient.RequestPickup(p)
+}
+
+//RequestPickup performs the call to the Ward API to schedule a pickup
+func (c *Client) RequestPickup(p *PickupRequest) (responseData PickupRequestResponse, err error) {
+	return c.RequestPickupContext(context.Background(), p)
+}
+
+//RequestPickupContext performs the call to the Ward API to schedule a pickup, stopping if ctx is cancelled
+//Use a ctx with a deadline to cap the total time of this one request, including retries.  Be aware that a
+//pickup may still be scheduled if ctx is cancelled after the request reached Ward.
+func (c *Client) RequestPickupContext(ctx context.Context, p *PickupRequest) (responseData PickupRequestResponse, err error) {
+	responseData, _, err = c.RequestPickupWithMeta(ctx, p)
+	return
+}
 
-	//make the call to the ward API
-	//set a timeout since golang doesn't set one by default and we don't want this to hang forever
-	//using application/x-www-form-encoded since this is what Ward's demo used
-	httpClient := http.Client{
-		Timeout: timeout,
+//RequestPickupWithMeta schedules a pickup the same as RequestPickupContext and also returns the http status code
+//and headers of Ward's response
+//meta is zero when no response was received, such as when the earlier response for an IdempotencyKey is
+//returned.
+func (c *Client) RequestPickupWithMeta(ctx context.Context, p *PickupRequest) (responseData PickupRequestResponse, meta ResponseMeta, err error) {
+	//track how long the call takes
+	start := c.now()
+	defer func() {
+		responseData.Duration = c.since(start)
+		c.observe(EndpointPickup, responseData.Duration, err)
+	}()
+
+	//return the earlier response if this pickup was already scheduled
+	//hold the key until the response is cached so a concurrent request with the same key can't also be sent
+	cache := c.getPickupCache()
+	if cache != nil && p.IdempotencyKey != "" {
+		unlock := c.pickupKeys.lock(p.IdempotencyKey)
+		defer unlock()
+
+		if cached, ok := cache.Get(p.IdempotencyKey); ok {
+			responseData = cached
+			return
+		}
 	}
-	res, err := httpClient.Post(pickupRequestURL, "application/x-www-form-encoded", strings.NewReader(xmlString))
+
+	//build the xml exactly as BuildPickupXML does
+	xmlString, err := c.BuildPickupXML(p)
 	if err != nil {
-		err = errors.Wrap(err, "ward.RequestPickup - could not make post request")
 		return
 	}
 
-	//read the response
-	body, err := ioutil.ReadAll(res.Body)
-	defer res.Body.Close()
+	//make the call to the ward API and read the response
+	//only retry when the pickup has an IdempotencyKey, otherwise a request that reached Ward but whose response
+	//was lost would be sent again and could schedule a duplicate pickup
+	var body []byte
+	body, meta, err = c.doRequest(ctx, EndpointPickup, c.pickupURL(p.Mode), xmlString, p.IdempotencyKey != "")
 	if err != nil {
-		err = errors.Wrap(err, "ward.RequestPickup - could not read response 1")
+		err = errors.Wrap(err, "ward.RequestPickup - could not make request")
 		return
 	}
 
-	err = xml.Unmarshal(body, &responseData)
+	err = unmarshalResponse(body, &responseData)
 	if err != nil {
-		err = errors.Wrap(err, "ward.RequestPickup - could not read response 2")
+		err = errors.Wrap(err, "ward.RequestPickup - could not read response")
 		return
 	}
 
+	//keep Ward's timestamp for auditing
+	responseData.CreateResult.Timestamp = parseServerTimestamp(body)
+
 	//check if data was returned meaning request was successful
-	//if not, reread the response data and log it
+	//if not, return the response data in the error so the caller can see why
 	if responseData.CreateResult.PickupConfirmation == "" {
-		log.Println("ward.RequestPickup - pickup request failed")
-		log.Printf(string(body))
-
-		var errorData map[string]interface{}
-		xml.Unmarshal(body, &errorData)
-
-		err = errors.New("ward.RequestPickup - pickup request failed")
-		log.Println(errorData)
+		err = &PickupError{
+			StatusCode: meta.StatusCode,
+			Message:    strings.TrimSpace(responseData.CreateResult.Message),
+			Body:       body,
+		}
+		c.logf("%s\n%s", err, body)
 		return
 	}
 
 	//pickup request successful
 	//response data will have confirmation info
+	//a message along with a confirmation is a caveat about the pickup, such as an appointment that could not
+	//be confirmed, so make sure it isn't missed
+	if msg := strings.TrimSpace(responseData.CreateResult.Message); msg != "" {
+		responseData.CreateResult.Warning = msg
+	}
+
+	//a different reference coming back means the response may not be for this request
+	if !responseData.CreateResult.ReferenceMatches(p.Shipment.RequestorReference) {
+		mismatch := "requestor reference " + strings.TrimSpace(responseData.CreateResult.RequestorReference) + " does not match " + strings.TrimSpace(p.Shipment.RequestorReference)
+		if responseData.CreateResult.Warning != "" {
+			mismatch = responseData.CreateResult.Warning + "; " + mismatch
+		}
+		responseData.CreateResult.Warning = mismatch
+	}
+
+	//remember the pickup so it isn't scheduled again
+	if cache != nil && p.IdempotencyKey != "" {
+		cache.Set(p.IdempotencyKey, responseData)
+	}
+
 	return
 }
 
@@ -238,11 +339,19 @@ func (p *PickupRequest) RequestPickup() (responseData PickupRequestResponse, err
 type RateQuoteRequest struct {
 	XMLName xml.Name `xml:"soap12:Envelope"`
 
+	//the envelope attributes are set based on the SOAP version when marshalled, see SetSOAPVersion
+	//these fields are no longer used and are kept for compatibility
 	XsiAttr    string `xml:"xmlns:xsi,attr"`    //http://www.w3.org/2001/XMLSchema-instance
 	XsdAttr    string `xml:"xmlns:xsd,attr"`    //http://www.w3.org/2001/XMLSchema
 	Soap12Attr string `xml:"xmlns:soap12,attr"` //http://www.w3.org/2003/05/soap-envelope
 
 	Request RateQuoteRequestInner `xml:"soap12:Body>request"`
+
+	//Mode chooses the test or production url for just this request, the client's mode is used by default
+	Mode Mode `xml:"-"`
+
+	//NoCache gets a fresh quote from Ward even if an identical quote is cached, see SetQuoteCache
+	NoCache bool `xml:"-"`
 }
 
 //RateQuoteRequestInner is the inner request data.  This has the actual details of the shipment
@@ -250,131 +359,287 @@ type RateQuoteRequest struct {
 type RateQuoteRequestInner struct {
 	Details            []RateQuoteDetailItem      `xml:"Details>DetailItem"`
 	Accessorials       []RateQuoteAccessorialItem `xml:"Accessorials>AccessorialItem"`
-	BillingTerms       string                     `xml:"BillingTerms"` //not sure what this is (prepaid/collect?)
+	BillingTerms       BillingTerms               `xml:"BillingTerms"` //who pays, see the BillingTerms constants, blank uses Ward's default
 	OriginCity         string                     `xml:"OriginCity"`
 	OriginState        string                     `xml:"OriginState"` //two char code
 	OriginZipcode      string                     `xml:"OriginZipcode"`
+	OriginCountry      string                     `xml:"OriginCountry,omitempty"` //US or CA, blank is US
 	DestinationCity    string                     `xml:"DestinationCity"`
 	DestinationState   string                     `xml:"DestinationState"` //who char code
 	DestinationZipcode string                     `xml:"DestinationZipcode"`
-	PalletCount        uint                       `xml:"PalletCount"` //should be sum of values from RateQuoteDetailItem pieces
-	Customer           string                     `xml:"Customer"`    //your Ward account number to get valid rates with
+	DestinationCountry string                     `xml:"DestinationCountry,omitempty"` //US or CA, blank is US
+	PalletCount        uint                       `xml:"PalletCount"`                  //should be sum of values from RateQuoteDetailItem pieces, filled in when zero
+	Customer           string                     `xml:"Customer"`                     //your Ward account number to get valid rates with
+
+	DeliveryAppointment Appointment `xml:"-"` //adds the matching appointment accessorial when the request is sent, see SetAllowUnverifiedAccessorials
 }
 
 //RateQuoteDetailItem is the details for the goods you need a rate quote on
 //one of these for each weight/pieces/class combo
 type RateQuoteDetailItem struct {
-	Weight uint `xml:"Weight"` //lbs
-	Pieces uint `xml:"Pieces"` // > 0
-	Class  uint `xml:"Class"`  //freight class, i.e. class 50, 55, 80, 100, etc.
+	Weight uint         `xml:"Weight"` //lbs, unless WeightUnit is set
+	Pieces uint         `xml:"Pieces"` // > 0
+	Class  FreightClass `xml:"Class"`  //freight class, i.e. class 50, 55, 85, 100, etc.
+
+	Commodity  string     `xml:"-"` //your sku or product identifier, used to check the class with a ClassResolver
+	WeightUnit WeightUnit `xml:"-"` //the unit of Weight, converted to lbs when sent
 }
 
 //RateQuoteAccessorialItem is a code to note special characteristics of this rate quote
-//protect from freeze, inside dock, liftgate, etc.  See ward api doc for codes to use.
+//protect from freeze, inside dock, liftgate, etc.  See the Accessorial constants for codes to use.
 type RateQuoteAccessorialItem struct {
-	Code string `xml:"Code"`
+	Code AccessorialCode `xml:"Code" json:"code"`
 
 	//in response only
-	Description string  `xml:"Description,omitempty"`
-	Amount      float64 `xml:"Amount,omitempty"`
+	Description string `xml:"Description,omitempty" json:"description,omitempty"`
+	Amount      Money  `xml:"Amount,omitempty" json:"amount,omitempty"`
 }
 
 //RateQuoteResponse is the format of data returned from a rate quote request when a rate is retrieved successfully
 type RateQuoteResponse struct {
-	XMLName      xml.Name                `xml:"Envelope"`                         //dont need "soap12"
-	CreateResult RateQuoteResponseResult `xml:"Body>CreateResponse>CreateResult"` //dont need "soap12"
+	XMLName      xml.Name                `xml:"Envelope" json:"-"`                                    //dont need "soap12"
+	CreateResult RateQuoteResponseResult `xml:"Body>CreateResponse>CreateResult" json:"createResult"` //dont need "soap12"
+
+	Duration time.Duration `xml:"-" json:"duration"` //how long the call to Ward took, including any retries
 }
 
 //RateQuoteResponseResult is the actual body of the pickup request response
 type RateQuoteResponseResult struct {
-	OriginServiceCenter      ServiceCenter `xml:"OriginServiceCenter"`
-	DestinationServiceCenter ServiceCenter `xml:"DestinationServiceCenter"`
-	CustomerService          struct {
-		Phone string
-	} `xml:"CustomerService"`
-	Customer             string                         `xml:"Customer"`
-	ShipZip              string                         `xml:"ShipZip"`
-	ConsZip              string                         `xml:"ConsZip"`
-	DiscountPercent      float64                        `xml:"DiscountPercent"`
-	DiscountAmount       float64                        `xml:"DiscountAmount"`
-	FuelSurchargePercent float64                        `xml:"FuelSurchargePercent"`
-	FuelSurchargeAmount  float64                        `xml:"FuelSurchargeAmount"`
-	NetCharge            float64                        `xml:"NetCharge"` //the actual rate quote dollar value
-	Tarrif               string                         `xml:"Tarrif"`
-	PricingEffectiveDate string                         `xml:"PricingEffectiveDate"` //mm/dd/yy
-	QuoteID              string                         `xml:"QuoteID"`
-	RateDetails          []RateQuoteResponseRateDetails `xml:"RateDetails"`
+	OriginServiceCenter      ServiceCenter                  `xml:"OriginServiceCenter" json:"originServiceCenter"`
+	DestinationServiceCenter ServiceCenter                  `xml:"DestinationServiceCenter" json:"destinationServiceCenter"`
+	CustomerService          CustomerServiceContact         `xml:"CustomerService" json:"customerService"` //general contact, see the service centers for terminal specific contacts
+	Customer                 string                         `xml:"Customer" json:"customer"`
+	ShipZip                  string                         `xml:"ShipZip" json:"shipZip"`
+	ConsZip                  string                         `xml:"ConsZip" json:"consZip"`
+	DiscountPercent          float64                        `xml:"DiscountPercent" json:"discountPercent"`
+	DiscountAmount           float64                        `xml:"DiscountAmount" json:"discountAmount"`
+	FuelSurchargePercent     float64                        `xml:"FuelSurchargePercent" json:"fuelSurchargePercent"`
+	FuelSurchargeAmount      float64                        `xml:"FuelSurchargeAmount" json:"fuelSurchargeAmount"`
+	NetCharge                float64                        `xml:"NetCharge" json:"netCharge"`                       //the actual rate quote dollar value, see NetChargeCents to avoid float rounding
+	Tariff                   string                         `xml:"Tarrif" json:"tariff"`                             //Ward misspells this, the xml tag matches Ward
+	PricingEffectiveDate     string                         `xml:"PricingEffectiveDate" json:"pricingEffectiveDate"` //mm/dd/yy, see ParsedPricingEffectiveDate
+	QuoteID                  string                         `xml:"QuoteID" json:"quoteId"`
+	RateDetails              []RateQuoteResponseRateDetails `xml:"RateDetails" json:"rateDetails"`
+
+	Timestamp time.Time `xml:"-" json:"timestamp"` //ward's server time from the response, zero if Ward didn't send one
 }
 
 //ServiceCenter is the freight terminal that handles a pickup or delivery
 type ServiceCenter struct {
-	ID          uint   `xml:"ID"`
-	Name        string `xml:"Name"`
-	Manager     string `xml:"Manager"`
-	Address     string `xml:"Address"`
-	City        string `xml:"City"`
-	State       string `xml:"State"` //two char code
-	ZipCode     string `xml:"ZipCode"`
-	TransitDays uint   `xml:"TransitDays"`
-	Fax         string `xml:"Fax"`
-	Phone       string `xml:"Phone"`
+	ID          uint   `xml:"ID" json:"id"`
+	Name        string `xml:"Name" json:"name"`
+	Manager     string `xml:"Manager" json:"manager"`
+	Address     string `xml:"Address" json:"address"`
+	City        string `xml:"City" json:"city"`
+	State       string `xml:"State" json:"state"` //two char code
+	ZipCode     string `xml:"ZipCode" json:"zipCode"`
+	TransitDays uint   `xml:"TransitDays" json:"transitDays"`
+	Fax         string `xml:"Fax" json:"fax"`
+	Phone       string `xml:"Phone" json:"phone"`
+
+	CustomerService CustomerServiceContact `xml:"CustomerService" json:"customerService"` //only if Ward provides a contact for this terminal
+}
+
+//IsZero checks if Ward left the service center empty
+//Some quotes come back without a populated service center (zero ID and no name).
+func (s ServiceCenter) IsZero() bool {
+	return s.ID == 0 && s.Name == ""
+}
+
+//CustomerServiceContact is who to contact at Ward about a shipment
+type CustomerServiceContact struct {
+	Name  string `xml:"Name" json:"name"`
+	Phone string `xml:"Phone" json:"phone"`
+	Email string `xml:"Email" json:"email"`
+}
+
+//IsZero checks if no contact information was provided
+func (c CustomerServiceContact) IsZero() bool {
+	return c.Name == "" && c.Phone == "" && c.Email == ""
 }
 
 //RateQuoteResponseRateDetails is some inner info about the rate quote
 type RateQuoteResponseRateDetails struct {
-	Class            string                     `xml:"Class"`  //this will have some leading and trailing zeros for some reason
-	Weight           uint                       `xml:"Weight"` //lbs
-	Amount           float64                    `xml:"Amount"`
-	Rate             float64                    `xml:"Rate"`
-	Pieces           uint                       `xml:"Pieces"`
-	RateAccessorials []RateQuoteAccessorialItem `xml:"RateAccessorials"`
+	Class            FreightClass               `xml:"Class" json:"class"`   //ward sends this with leading and trailing zeros, which are removed when parsed
+	Weight           uint                       `xml:"Weight" json:"weight"` //lbs
+	Amount           float64                    `xml:"Amount" json:"amount"`
+	Rate             float64                    `xml:"Rate" json:"rate"`
+	Pieces           uint                       `xml:"Pieces" json:"pieces"`
+	RateAccessorials []RateQuoteAccessorialItem `xml:"RateAccessorials" json:"rateAccessorials"`
 }
 
-//RateQuote performs the call to the Ward API to get a rate quote
+//rateDetailsElement is one RateDetails element in a rate quote response
+//Ward's documentation shows a RateDetails element for each rate detail, but a .NET service can also wrap the
+//list in a single RateDetails element with a RateDetail child element per rate detail.  Both are read, see
+//RateQuoteResponseResult.UnmarshalXML.
+type rateDetailsElement struct {
+	RateQuoteResponseRateDetails
+	Wrapped []RateQuoteResponseRateDetails `xml:"RateDetail"`
+}
+
+//details returns the rate details in the element, each RateDetail child if there are any or else the element itself
+func (e rateDetailsElement) details() []RateQuoteResponseRateDetails {
+	if len(e.Wrapped) > 0 {
+		return e.Wrapped
+	}
+
+	return []RateQuoteResponseRateDetails{e.RateQuoteResponseRateDetails}
+}
+
+//UnmarshalXML reads a rate quote result, capturing every rate detail whether or not Ward wraps the list
+func (r *RateQuoteResponseResult) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	//plain doesn't have this method so decoding into it doesn't recurse
+	type plain RateQuoteResponseResult
+	var aux struct {
+		plain
+		RateDetails []rateDetailsElement `xml:"RateDetails"`
+	}
+
+	if err := d.DecodeElement(&aux, &start); err != nil {
+		return err
+	}
+
+	*r = RateQuoteResponseResult(aux.plain)
+	r.RateDetails = nil
+	for _, e := range aux.RateDetails {
+		r.RateDetails = append(r.RateDetails, e.details()...)
+	}
+
+	return nil
+}
+
+//RateQuote performs the call to the Ward API to get a rate quote using the default client
 func (p *RateQuoteRequest) RateQuote() (responseData RateQuoteResponse, err error) {
-	//add xml attributes
-	p.XsdAttr = xsdAttr
-	p.XsiAttr = xsiAttr
-	p.Soap12Attr = soap12Attr
+	return defaultClient.RateQuote(p)
+}
 
-	//convert the pickup request to an xml
-	xmlBytes, err := xml.Marshal(p)
+//RateQuote performs the call to the Ward API to get a rate quote
+func (c *Client) RateQuote(p *RateQuoteRequest) (responseData RateQuoteResponse, err error) {
+	return c.RateQuoteContext(context.Background(), p)
+}
+
+//RateQuoteContext performs the call to the Ward API to get a rate quote, stopping if ctx is cancelled
+func (c *Client) RateQuoteContext(ctx context.Context, p *RateQuoteRequest) (responseData RateQuoteResponse, err error) {
+	responseData, _, err = c.RateQuoteWithMeta(ctx, p)
+	return
+}
+
+//RateQuoteWithMeta gets a rate quote the same as RateQuoteContext and also returns the http status code and
+//headers of Ward's response
+func (c *Client) RateQuoteWithMeta(ctx context.Context, p *RateQuoteRequest) (responseData RateQuoteResponse, meta ResponseMeta, err error) {
+	//track how long the call takes
+	start := c.now()
+	defer func() {
+		responseData.Duration = c.since(start)
+		c.observe(EndpointRateQuote, responseData.Duration, err)
+	}()
+
+	//build the xml exactly as BuildRateQuoteXML does
+	xmlString, err := c.BuildRateQuoteXML(p)
 	if err != nil {
-		err = errors.Wrap(err, "ward.RateQuote - could not marshal xml")
 		return
 	}
 
-	//add the xml header and an ending blank line
-	//need both to get request to work for some reason
-	xmlString := xml.Header + string(xmlBytes) + "\n"
-
-	//make the call to the ward API
-	//set a timeout since golang doesn't set one by default and we don't want this to hang forever
-	//using application/x-www-form-encoded since this is what Ward's demo used
-	httpClient := http.Client{
-		Timeout: timeout,
+	//return a recent identical quote instead of asking Ward again
+	endpointURL := c.rateQuoteURL(p.Mode)
+	cache := c.getQuoteCache()
+	cacheKey := quoteCacheKey(endpointURL, xmlString)
+	if cache != nil && !p.NoCache {
+		if cached, ok := cache.get(cacheKey, c.now()); ok {
+			responseData = cached
+			return
+		}
 	}
-	res, err := httpClient.Post(rateQuoteURL, "application/x-www-form-encoded", strings.NewReader(xmlString))
+
+	//make the call to the ward API and read the response
+	var body []byte
+	body, meta, err = c.doRequest(ctx, EndpointRateQuote, endpointURL, xmlString, true)
 	if err != nil {
-		err = errors.Wrap(err, "ward.RateQuote - could not make post request")
+		err = errors.Wrap(err, "ward.RateQuote - could not make request")
 		return
 	}
 
-	//read the response
-	body, err := ioutil.ReadAll(res.Body)
-	defer res.Body.Close()
+	err = unmarshalResponse(body, &responseData)
 	if err != nil {
-		err = errors.Wrap(err, "ward.RateQuote - could not read response 1")
+		err = errors.Wrap(err, "ward.RateQuote - could not read response")
 		return
 	}
 
-	err = xml.Unmarshal(body, &responseData)
-	if err != nil {
-		err = errors.Wrap(err, "ward.RateQuote - could not read response 2")
+	//keep Ward's timestamp for auditing
+	responseData.CreateResult.Timestamp = parseServerTimestamp(body)
+
+	//check if a rate was returned, same as the empty confirmation check for pickups
+	//without this a failed quote would look like a $0 quote
+	r := responseData.CreateResult
+	if r.QuoteID == "" && r.NetCharge == 0 && len(r.RateDetails) == 0 {
+		err = &QuoteError{
+			StatusCode: meta.StatusCode,
+			Body:       body,
+		}
+		c.logf("%s\n%s", err, body)
 		return
 	}
 
 	//rate quote was successful
 	//response data will have confirmation info
+	//cache even when NoCache is set so the fresh quote is used by later requests
+	if cache != nil {
+		cache.set(cacheKey, responseData, c.now())
+	}
+
+	return
+}
+
+//readResponseBody reads the entire body of a response from Ward and closes it
+//Ward is slow and sometimes trickles the response back.  If the connection drops mid-stream we end up
+//with a partial body that fails to unmarshal with a confusing error.  Check for a short read, either an
+//unexpected EOF or fewer bytes than the Content-Length header said to expect, and return ErrTruncatedResponse
+//instead so the caller knows this was a dropped connection.
+func readResponseBody(res *http.Response) (body []byte, err error) {
+	defer res.Body.Close()
+
+	body, err = ioutil.ReadAll(res.Body)
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		err = ErrTruncatedResponse
+		return
+	} else if err != nil {
+		return
+	}
+
+	//Content-Length is -1 when unknown (chunked responses)
+	if res.ContentLength > 0 && int64(len(body)) < res.ContentLength {
+		err = ErrTruncatedResponse
+		return
+	}
+
+	//decompress after checking the length since Content-Length is the size of the compressed body
+	if !res.Uncompressed && strings.EqualFold(strings.TrimSpace(res.Header.Get("Content-Encoding")), "gzip") {
+		body, err = gunzip(body)
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+//gunzip decompresses a gzip encoded response body
+func gunzip(compressed []byte) (body []byte, err error) {
+	zr, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		err = errors.Wrap(err, "ward.gunzip - could not read gzip response")
+		return
+	}
+	defer zr.Close()
+
+	body, err = ioutil.ReadAll(zr)
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		err = ErrTruncatedResponse
+		return
+	} else if err != nil {
+		err = errors.Wrap(err, "ward.gunzip - could not decompress response")
+		return
+	}
+
 	return
 }
@@ -0,0 +1,310 @@
+package ward
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+//soapFaultXML is a SOAP 1.2 fault from Ward
+const soapFaultXML = `<?xml version="1.0" encoding="utf-8"?>
+<soap:Envelope xmlns:soap="http://www.w3.org/2003/05/soap-envelope"><soap:Body><soap:Fault>
+<soap:Code><soap:Value>soap:Sender</soap:Value></soap:Code>
+<soap:Reason><soap:Text xml:lang="en">Server was unable to read request.</soap:Text></soap:Reason>
+</soap:Fault></soap:Body></soap:Envelope>`
+
+//quoteEmptyXML is a response to a rate quote with no rate
+const quoteEmptyXML = `<?xml version="1.0" encoding="utf-8"?>
+<soap:Envelope xmlns:soap="http://www.w3.org/2003/05/soap-envelope"><soap:Body><CreateResponse><CreateResult>
+</CreateResult></CreateResponse></soap:Body></soap:Envelope>`
+
+//frozenClient returns a test client whose clock is frozenNow
+func frozenClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	c, _ := newTestClient(t, handler)
+	c.SetClock(frozenClock(frozenNow))
+	return c
+}
+
+func TestRequestPickup(t *testing.T) {
+	var req []byte
+	c := frozenClient(t, capture(&req, http.StatusOK, pickupSuccessXML))
+
+	p := testPickupRequest()
+	p.ShipperInfo.PickupDate = frozenPickupDate
+	res, err := c.RequestPickup(&p)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	r := res.CreateResult
+	if r.PickupConfirmation != "PU123456" || r.PickupTerminal != "PIT" {
+		t.Fatalf("unexpected response %+v", r)
+	}
+
+	golden(t, "pickup_request.xml", req)
+}
+
+func TestRateQuote(t *testing.T) {
+	var req []byte
+	c := frozenClient(t, capture(&req, http.StatusOK, quoteSuccessXML))
+
+	q := testRateQuoteRequest()
+	res, err := c.RateQuote(&q)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	r := res.CreateResult
+	if r.QuoteID != "Q98765" || r.NetCharge != 250.75 || len(r.RateDetails) != 1 {
+		t.Fatalf("unexpected response %+v", r)
+	}
+
+	golden(t, "ratequote_request.xml", req)
+}
+
+func TestRequestPickupErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		check   func(err error) bool
+	}{
+		{"empty confirmation", respond(http.StatusOK, pickupEmptyXML), func(err error) bool {
+			var e *PickupError
+			return errors.As(err, &e) && e.Message == "NO DRIVERS AVAILABLE"
+		}},
+		{"soap fault", respond(http.StatusInternalServerError, soapFaultXML), func(err error) bool {
+			var e *SOAPFault
+			return errors.As(err, &e) && e.StatusCode == http.StatusInternalServerError
+		}},
+		{"http 5xx", respond(http.StatusInternalServerError, "<html>server error</html>"), func(err error) bool {
+			var e *HTTPError
+			return errors.As(err, &e) && e.StatusCode == http.StatusInternalServerError
+		}},
+		{"timeout", slow(5*time.Second, pickupSuccessXML), func(err error) bool {
+			return categorizeError(err) == ErrorCategoryTimeout
+		}},
+	}
+
+	for _, tt := range tests {
+		c := frozenClient(t, tt.handler)
+		c.SetTimeout(50 * time.Millisecond)
+
+		p := testPickupRequest()
+		p.ShipperInfo.PickupDate = frozenPickupDate
+		_, err := c.RequestPickup(&p)
+		if err == nil || !tt.check(err) {
+			t.Errorf("%s: unexpected error %v", tt.name, err)
+		}
+	}
+}
+
+func TestRateQuoteErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		check   func(err error) bool
+	}{
+		{"empty quote", respond(http.StatusOK, quoteEmptyXML), func(err error) bool {
+			var e *QuoteError
+			return errors.As(err, &e) && e.StatusCode == http.StatusOK
+		}},
+		{"soap fault", respond(http.StatusInternalServerError, soapFaultXML), func(err error) bool {
+			var e *SOAPFault
+			return errors.As(err, &e) && e.Reason == "Server was unable to read request."
+		}},
+		{"http 5xx", respond(http.StatusBadGateway, "<html>bad gateway</html>"), func(err error) bool {
+			var e *HTTPError
+			return errors.As(err, &e) && e.StatusCode == http.StatusBadGateway
+		}},
+		{"timeout", slow(5*time.Second, quoteSuccessXML), func(err error) bool {
+			return categorizeError(err) == ErrorCategoryTimeout
+		}},
+	}
+
+	for _, tt := range tests {
+		c := frozenClient(t, tt.handler)
+		c.SetTimeout(50 * time.Millisecond)
+
+		q := testRateQuoteRequest()
+		_, err := c.RateQuote(&q)
+		if err == nil || !tt.check(err) {
+			t.Errorf("%s: unexpected error %v", tt.name, err)
+		}
+	}
+}
+
+//fillFields sets every string field of a struct to the field's name and every number to its index, so each
+//element in the xml shows which field it came from
+func fillFields(v reflect.Value) {
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Field(i)
+		if !f.CanSet() {
+			continue
+		}
+
+		switch f.Kind() {
+		case reflect.String:
+			f.SetString(v.Type().Field(i).Name)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			f.SetUint(uint64(i + 1))
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			f.SetInt(int64(i + 1))
+		case reflect.Float32, reflect.Float64:
+			f.SetFloat(float64(i + 1))
+		}
+	}
+}
+
+func TestPickupRequestTagsGolden(t *testing.T) {
+	var p PickupRequest
+	fillFields(reflect.ValueOf(&p.ShipperInfo).Elem())
+	fillFields(reflect.ValueOf(&p.Shipment).Elem())
+
+	xmlString, err := NewClient().buildSOAPBody(p.envelopeBody())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	//every element name must match what Ward's documentation names the field
+	golden(t, "pickup_request_fields.xml", []byte(xmlString))
+}
+
+//checkJSONTags fails if any exported field of t, or of a struct it contains, has no json tag
+func checkJSONTags(t *testing.T, typ reflect.Type, seen map[reflect.Type]bool) {
+	t.Helper()
+
+	for typ.Kind() == reflect.Slice || typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct || typ.PkgPath() != reflect.TypeOf(Client{}).PkgPath() || seen[typ] {
+		return
+	}
+	seen[typ] = true
+
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		if f.Anonymous {
+			checkJSONTags(t, f.Type, seen)
+			continue
+		}
+
+		tag := f.Tag.Get("json")
+		if tag == "" {
+			t.Errorf("%s.%s has no json tag", typ.Name(), f.Name)
+			continue
+		}
+		if tag != "-" && strings.ToLower(tag[:1]) != tag[:1] {
+			t.Errorf("%s.%s json tag %q should start lowercase", typ.Name(), f.Name, tag)
+		}
+
+		checkJSONTags(t, f.Type, seen)
+	}
+}
+
+func TestResponseJSONTags(t *testing.T) {
+	seen := map[reflect.Type]bool{}
+	for _, r := range []interface{}{
+		PickupRequestResponse{},
+		RateQuoteResponse{},
+		CancelPickupResponse{},
+		TrackResponse{},
+		BillOfLadingResponse{},
+	} {
+		checkJSONTags(t, reflect.TypeOf(r), seen)
+	}
+}
+
+func TestResponseJSON(t *testing.T) {
+	var res PickupRequestResponse
+	if err := unmarshalResponse([]byte(pickupSuccessXML), &res); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	b, err := json.Marshal(res)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var m struct {
+		XMLName      interface{}
+		CreateResult map[string]interface{} `json:"createResult"`
+	}
+	if err := json.Unmarshal(b, &m); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m.XMLName != nil {
+		t.Fatal("expected XMLName to be left out")
+	}
+	if got := m.CreateResult["pickupConfirmation"]; got != "PU123456" {
+		t.Fatalf("expected createResult.pickupConfirmation to be PU123456, got %v", got)
+	}
+}
+
+func TestRateQuoteResponseTariff(t *testing.T) {
+	var res RateQuoteResponse
+	if err := unmarshalResponse([]byte(quoteSuccessXML), &res); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.CreateResult.Tariff != "WARD500" {
+		t.Fatalf("expected the misspelled Tarrif element to be read, got %q", res.CreateResult.Tariff)
+	}
+
+	b, err := json.Marshal(res.CreateResult)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(string(b), `"tariff":"WARD500"`) || strings.Contains(string(b), "tarrif") {
+		t.Fatalf("expected the json to be spelled tariff, got %s", b)
+	}
+}
+
+func TestRateQuoteResponseRateDetailsLayouts(t *testing.T) {
+	const first = `<Class>0070.0</Class><Weight>1000</Weight><Amount>300.00</Amount><Rate>30.00</Rate><Pieces>2</Pieces>`
+	const second = `<Class>0085.0</Class><Weight>500</Weight><Amount>125.00</Amount><Rate>25.00</Rate><Pieces>1</Pieces>`
+
+	tests := []struct {
+		name    string
+		details string
+		classes []FreightClass
+	}{
+		{"one element per detail", `<RateDetails>` + first + `</RateDetails><RateDetails>` + second + `</RateDetails>`, []FreightClass{Class70, Class85}},
+		{"wrapped", `<RateDetails><RateDetail>` + first + `</RateDetail><RateDetail>` + second + `</RateDetail></RateDetails>`, []FreightClass{Class70, Class85}},
+		{"wrapped single", `<RateDetails><RateDetail>` + first + `</RateDetail></RateDetails>`, []FreightClass{Class70}},
+		{"empty detail", `<RateDetails><Amount>0</Amount></RateDetails>`, []FreightClass{0}},
+		{"none", ``, nil},
+	}
+
+	for _, tt := range tests {
+		body := strings.Replace(quoteSuccessXML, `<RateDetails>`+first+`</RateDetails>`, tt.details, 1)
+
+		var res RateQuoteResponse
+		if err := unmarshalResponse([]byte(body), &res); err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+
+		details := res.CreateResult.RateDetails
+		if len(details) != len(tt.classes) {
+			t.Fatalf("%s: expected %d rate details, got %+v", tt.name, len(tt.classes), details)
+		}
+		for i, class := range tt.classes {
+			if details[i].Class != class {
+				t.Errorf("%s: expected rate detail %d to be class %v, got %v", tt.name, i, class, details[i].Class)
+			}
+		}
+		if len(details) == 2 && (details[1].Weight != 500 || details[1].Amount != 125 || details[1].Pieces != 1) {
+			t.Errorf("%s: unexpected second rate detail %+v", tt.name, details[1])
+		}
+		if res.CreateResult.QuoteID != "Q98765" {
+			t.Errorf("%s: expected the rest of the result to be read, got %+v", tt.name, res.CreateResult)
+		}
+	}
+}
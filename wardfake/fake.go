@@ -0,0 +1,162 @@
+//Package wardfake provides a fake Ward client for testing code that uses the ward package
+//FakeClient implements ward.WardAPI without making any network calls.  Set the responses and errors each call
+//should return, or set a func to decide per request, then check which requests were made.
+package wardfake
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	ward "github.com/coreymgilmore/wardtrucking"
+)
+
+//FakeClient is a ward.WardAPI that returns programmed responses
+//The zero value is ready to use and returns zero responses with no errors.  A func, when set, is used instead
+//of the matching response and error.  FakeClient is safe for concurrent use.
+type FakeClient struct {
+	mu sync.Mutex
+
+	PickupResponse ward.PickupRequestResponse
+	PickupErr      error
+	PickupFunc     func(p *ward.PickupRequest) (ward.PickupRequestResponse, error)
+
+	QuoteResponse ward.RateQuoteResponse
+	QuoteErr      error
+	QuoteFunc     func(p *ward.RateQuoteRequest) (ward.RateQuoteResponse, error)
+
+	CancelResponse ward.CancelPickupResponse
+	CancelErr      error
+
+	TrackResponse ward.TrackResponse
+	TrackErr      error
+
+	GetQuoteResponse ward.RateQuoteResponse
+	GetQuoteErr      error
+
+	BillOfLadingResponse ward.BillOfLadingResponse
+	BillOfLadingErr      error
+
+	ServiceAreaResponse ward.ServiceArea
+	ServiceAreaErr      error
+
+	PingLatency time.Duration
+	PingErr     error
+
+	//requests made, in order, for checking what your code sent
+	Pickups       []ward.PickupRequest
+	Quotes        []ward.RateQuoteRequest
+	Cancellations []string
+	Tracks        []ward.TrackRequest
+	QuoteLookups  []string
+	BillsOfLading []ward.BillOfLadingRequest
+	ServiceAreas  [][2]string //origin and destination zip codes
+	Pings         int
+}
+
+//make sure FakeClient always satisfies ward.WardAPI
+var _ ward.WardAPI = (*FakeClient)(nil)
+
+//RequestPickup records the request and returns the programmed pickup response
+func (f *FakeClient) RequestPickup(p *ward.PickupRequest) (ward.PickupRequestResponse, error) {
+	return f.RequestPickupContext(context.Background(), p)
+}
+
+//RequestPickupContext records the request and returns the programmed pickup response, or ctx's error if it is done
+func (f *FakeClient) RequestPickupContext(ctx context.Context, p *ward.PickupRequest) (ward.PickupRequestResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return ward.PickupRequestResponse{}, err
+	}
+
+	f.mu.Lock()
+	f.Pickups = append(f.Pickups, *p)
+	fn, res, err := f.PickupFunc, f.PickupResponse, f.PickupErr
+	f.mu.Unlock()
+
+	if fn != nil {
+		return fn(p)
+	}
+
+	return res, err
+}
+
+//RateQuote records the request and returns the programmed quote response
+func (f *FakeClient) RateQuote(p *ward.RateQuoteRequest) (ward.RateQuoteResponse, error) {
+	return f.RateQuoteContext(context.Background(), p)
+}
+
+//RateQuoteContext records the request and returns the programmed quote response, or ctx's error if it is done
+func (f *FakeClient) RateQuoteContext(ctx context.Context, p *ward.RateQuoteRequest) (ward.RateQuoteResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return ward.RateQuoteResponse{}, err
+	}
+
+	f.mu.Lock()
+	f.Quotes = append(f.Quotes, *p)
+	fn, res, err := f.QuoteFunc, f.QuoteResponse, f.QuoteErr
+	f.mu.Unlock()
+
+	if fn != nil {
+		return fn(p)
+	}
+
+	return res, err
+}
+
+//CancelPickup records the confirmation and returns the programmed cancellation response
+func (f *FakeClient) CancelPickup(confirmation string) (ward.CancelPickupResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.Cancellations = append(f.Cancellations, confirmation)
+	return f.CancelResponse, f.CancelErr
+}
+
+//TrackShipment records the request and returns the programmed tracking response
+func (f *FakeClient) TrackShipment(t ward.TrackRequest) (ward.TrackResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.Tracks = append(f.Tracks, t)
+	return f.TrackResponse, f.TrackErr
+}
+
+//GetQuote records the quote id and returns the programmed quote lookup response
+func (f *FakeClient) GetQuote(quoteID string) (ward.RateQuoteResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.QuoteLookups = append(f.QuoteLookups, quoteID)
+	return f.GetQuoteResponse, f.GetQuoteErr
+}
+
+//SubmitBillOfLading records the request and returns the programmed bill of lading response
+func (f *FakeClient) SubmitBillOfLading(b *ward.BillOfLadingRequest) (ward.BillOfLadingResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.BillsOfLading = append(f.BillsOfLading, *b)
+	return f.BillOfLadingResponse, f.BillOfLadingErr
+}
+
+//CheckServiceArea records the lane and returns the programmed service area
+func (f *FakeClient) CheckServiceArea(originZip, destinationZip string) (ward.ServiceArea, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.ServiceAreas = append(f.ServiceAreas, [2]string{originZip, destinationZip})
+	return f.ServiceAreaResponse, f.ServiceAreaErr
+}
+
+//Ping counts the call and returns the programmed latency, or ctx's error if it is done
+func (f *FakeClient) Ping(ctx context.Context) (time.Duration, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.Pings++
+	return f.PingLatency, f.PingErr
+}
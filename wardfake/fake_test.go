@@ -0,0 +1,176 @@
+package wardfake
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	ward "github.com/coreymgilmore/wardtrucking"
+)
+
+func TestFakeClientResponses(t *testing.T) {
+	f := &FakeClient{
+		PickupResponse:       PickupScheduled("PU123456"),
+		QuoteResponse:        Quote("Q98765", 250.75, 2),
+		CancelResponse:       PickupCancelled("PU123456"),
+		GetQuoteResponse:     Quote("Q98765", 250.75, 2),
+		BillOfLadingResponse: ward.BillOfLadingResponse{CreateResult: ward.BillOfLadingResponseResult{BOLNumber: "BOL555"}},
+		ServiceAreaResponse:  ward.ServiceArea{OriginZipcode: "15222", DestinationZipcode: "44101", TransitDays: 1},
+		PingLatency:          25 * time.Millisecond,
+	}
+	var api ward.WardAPI = f
+
+	p := ward.PickupRequest{}
+	p.Shipment.RequestorReference = "PO-1001"
+	if res, err := api.RequestPickup(&p); err != nil || res.CreateResult.PickupConfirmation != "PU123456" {
+		t.Fatalf("RequestPickup: unexpected response %+v, %v", res, err)
+	}
+
+	q := ward.RateQuoteRequest{}
+	q.Request.OriginZipcode = "15222"
+	if res, err := api.RateQuote(&q); err != nil || res.CreateResult.QuoteID != "Q98765" {
+		t.Fatalf("RateQuote: unexpected response %+v, %v", res, err)
+	}
+	if res, err := api.CancelPickup("PU123456"); err != nil || res.CreateResult.Cancelled != "Y" {
+		t.Fatalf("CancelPickup: unexpected response %+v, %v", res, err)
+	}
+	if _, err := api.TrackShipment(ward.TrackRequest{ProNumber: "123456789"}); err != nil {
+		t.Fatalf("TrackShipment: unexpected error: %v", err)
+	}
+	if res, err := api.GetQuote("Q98765"); err != nil || res.CreateResult.NetCharge != 250.75 {
+		t.Fatalf("GetQuote: unexpected response %+v, %v", res, err)
+	}
+
+	b := ward.BillOfLadingRequest{Customer: "12345"}
+	if res, err := api.SubmitBillOfLading(&b); err != nil || res.CreateResult.BOLNumber != "BOL555" {
+		t.Fatalf("SubmitBillOfLading: unexpected response %+v, %v", res, err)
+	}
+	if area, err := api.CheckServiceArea("15222", "44101"); err != nil || area.TransitDays != 1 {
+		t.Fatalf("CheckServiceArea: unexpected response %+v, %v", area, err)
+	}
+	if latency, err := api.Ping(context.Background()); err != nil || latency != 25*time.Millisecond {
+		t.Fatalf("Ping: unexpected response %s, %v", latency, err)
+	}
+
+	//every request is recorded
+	if len(f.Pickups) != 1 || f.Pickups[0].Shipment.RequestorReference != "PO-1001" {
+		t.Errorf("unexpected pickups %+v", f.Pickups)
+	}
+	if len(f.Quotes) != 1 || f.Quotes[0].Request.OriginZipcode != "15222" {
+		t.Errorf("unexpected quotes %+v", f.Quotes)
+	}
+	if !reflect.DeepEqual(f.Cancellations, []string{"PU123456"}) {
+		t.Errorf("unexpected cancellations %v", f.Cancellations)
+	}
+	if !reflect.DeepEqual(f.Tracks, []ward.TrackRequest{{ProNumber: "123456789"}}) {
+		t.Errorf("unexpected tracks %v", f.Tracks)
+	}
+	if !reflect.DeepEqual(f.QuoteLookups, []string{"Q98765"}) {
+		t.Errorf("unexpected quote lookups %v", f.QuoteLookups)
+	}
+	if len(f.BillsOfLading) != 1 || f.BillsOfLading[0].Customer != "12345" {
+		t.Errorf("unexpected bills of lading %+v", f.BillsOfLading)
+	}
+	if !reflect.DeepEqual(f.ServiceAreas, [][2]string{{"15222", "44101"}}) {
+		t.Errorf("unexpected service areas %v", f.ServiceAreas)
+	}
+	if f.Pings != 1 {
+		t.Errorf("expected 1 ping, got %d", f.Pings)
+	}
+}
+
+func TestFakeClientErrors(t *testing.T) {
+	errDown := errors.New("ward is down")
+	f := &FakeClient{
+		PickupErr:       PickupRejected("NO DRIVERS AVAILABLE"),
+		QuoteErr:        QuoteRejected(),
+		CancelErr:       errDown,
+		TrackErr:        ward.ErrShipmentNotFound,
+		GetQuoteErr:     ward.ErrQuoteExpired,
+		BillOfLadingErr: errDown,
+		ServiceAreaErr:  ward.ErrLaneNotServed,
+		PingErr:         errDown,
+	}
+	var api ward.WardAPI = f
+
+	var pickupErr *ward.PickupError
+	if _, err := api.RequestPickup(&ward.PickupRequest{}); !errors.As(err, &pickupErr) || pickupErr.Message != "NO DRIVERS AVAILABLE" {
+		t.Errorf("RequestPickup: expected a *ward.PickupError, got %v", err)
+	}
+	var quoteErr *ward.QuoteError
+	if _, err := api.RateQuote(&ward.RateQuoteRequest{}); !errors.As(err, &quoteErr) {
+		t.Errorf("RateQuote: expected a *ward.QuoteError, got %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		err      error
+		expected error
+	}{
+		{"CancelPickup", second(api.CancelPickup("PU123456")), errDown},
+		{"TrackShipment", second(api.TrackShipment(ward.TrackRequest{ProNumber: "123456789"})), ward.ErrShipmentNotFound},
+		{"GetQuote", second(api.GetQuote("Q98765")), ward.ErrQuoteExpired},
+		{"SubmitBillOfLading", second(api.SubmitBillOfLading(&ward.BillOfLadingRequest{})), errDown},
+		{"CheckServiceArea", second(api.CheckServiceArea("15222", "44101")), ward.ErrLaneNotServed},
+		{"Ping", second(api.Ping(context.Background())), errDown},
+	}
+	for _, tt := range tests {
+		if !errors.Is(tt.err, tt.expected) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, tt.err)
+		}
+	}
+}
+
+func TestFakeClientFuncs(t *testing.T) {
+	f := &FakeClient{
+		PickupFunc: func(p *ward.PickupRequest) (ward.PickupRequestResponse, error) {
+			return PickupScheduled("PU-" + p.Shipment.RequestorReference), nil
+		},
+		QuoteFunc: func(p *ward.RateQuoteRequest) (ward.RateQuoteResponse, error) {
+			return Quote("Q-"+p.Request.DestinationZipcode, 100, 1), nil
+		},
+
+		//the funcs are used instead of these
+		PickupErr: errors.New("not used"),
+		QuoteErr:  errors.New("not used"),
+	}
+
+	p := ward.PickupRequest{}
+	p.Shipment.RequestorReference = "PO-1001"
+	if res, err := f.RequestPickup(&p); err != nil || res.CreateResult.PickupConfirmation != "PU-PO-1001" {
+		t.Fatalf("RequestPickup: unexpected response %+v, %v", res, err)
+	}
+
+	q := ward.RateQuoteRequest{}
+	q.Request.DestinationZipcode = "44101"
+	if res, err := f.RateQuote(&q); err != nil || res.CreateResult.QuoteID != "Q-44101" {
+		t.Fatalf("RateQuote: unexpected response %+v, %v", res, err)
+	}
+}
+
+func TestFakeClientContextDone(t *testing.T) {
+	f := &FakeClient{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := f.RequestPickupContext(ctx, &ward.PickupRequest{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("RequestPickupContext: expected context.Canceled, got %v", err)
+	}
+	if _, err := f.RateQuoteContext(ctx, &ward.RateQuoteRequest{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("RateQuoteContext: expected context.Canceled, got %v", err)
+	}
+	if _, err := f.Ping(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Ping: expected context.Canceled, got %v", err)
+	}
+	if len(f.Pickups) != 0 || len(f.Quotes) != 0 || f.Pings != 0 {
+		t.Errorf("expected nothing to be recorded, got %+v", f)
+	}
+}
+
+//second returns the error from a call that returns a value and an error
+func second(_ interface{}, err error) error {
+	return err
+}
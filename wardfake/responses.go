@@ -0,0 +1,47 @@
+package wardfake
+
+import ward "github.com/coreymgilmore/wardtrucking"
+
+//PickupScheduled returns a response for a pickup Ward scheduled as requested
+func PickupScheduled(confirmation string) ward.PickupRequestResponse {
+	var r ward.PickupRequestResponse
+	r.CreateResult.PickupConfirmation = confirmation
+	return r
+}
+
+//PickupScheduledWithWarning returns a response for a pickup Ward scheduled with a caveat
+func PickupScheduledWithWarning(confirmation, warning string) ward.PickupRequestResponse {
+	r := PickupScheduled(confirmation)
+	r.CreateResult.Message = warning
+	r.CreateResult.Warning = warning
+	return r
+}
+
+//PickupRejected returns the error RequestPickup gives when Ward doesn't schedule a pickup
+func PickupRejected(message string) error {
+	return &ward.PickupError{StatusCode: 200, Message: message}
+}
+
+//Quote returns a response for a successful rate quote
+//transitDays is set on the destination service center, as Ward does.
+func Quote(quoteID string, netCharge float64, transitDays uint) ward.RateQuoteResponse {
+	var r ward.RateQuoteResponse
+	r.CreateResult.QuoteID = quoteID
+	r.CreateResult.NetCharge = netCharge
+	r.CreateResult.OriginServiceCenter = ward.ServiceCenter{ID: 1, Name: "ORIGIN"}
+	r.CreateResult.DestinationServiceCenter = ward.ServiceCenter{ID: 2, Name: "DESTINATION", TransitDays: transitDays}
+	return r
+}
+
+//QuoteRejected returns the error RateQuote gives when Ward doesn't return a rate
+func QuoteRejected() error {
+	return &ward.QuoteError{StatusCode: 200}
+}
+
+//PickupCancelled returns a response for a pickup Ward cancelled
+func PickupCancelled(confirmation string) ward.CancelPickupResponse {
+	var r ward.CancelPickupResponse
+	r.CreateResult.PickupConfirmation = confirmation
+	r.CreateResult.Cancelled = "Y"
+	return r
+}
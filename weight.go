@@ -0,0 +1,45 @@
+package ward
+
+import "math"
+
+//WeightUnit is the unit a weight is given in
+//Ward only accepts pounds so weights in other units are converted to pounds when a request is sent.
+type WeightUnit int
+
+//supported weight units
+const (
+	Pounds    WeightUnit = iota //default
+	Kilograms                   //converted to pounds, rounded to the nearest pound
+)
+
+//poundsPerKilogram is used to convert kilograms to pounds
+const poundsPerKilogram = 2.20462262185
+
+//toPounds converts a weight in a unit to whole pounds
+//Kilograms are rounded to the nearest pound, halves round up, so 100 kg is 220 lbs.
+func toPounds(weight uint, unit WeightUnit) uint {
+	if unit != Kilograms {
+		return weight
+	}
+
+	return uint(math.Round(float64(weight) * poundsPerKilogram))
+}
+
+//normalizeWeight converts the weight to pounds
+//The unit is reset to pounds so converting again doesn't change anything.
+func (s *PickupRequestShipment) normalizeWeight() {
+	s.Weight = toPounds(s.Weight, s.WeightUnit)
+	s.WeightUnit = Pounds
+	return
+}
+
+//normalizeWeights converts the weight of each detail item to pounds, see normalizeWeight
+func (r *RateQuoteRequestInner) normalizeWeights() {
+	for i := range r.Details {
+		d := &r.Details[i]
+		d.Weight = toPounds(d.Weight, d.WeightUnit)
+		d.WeightUnit = Pounds
+	}
+
+	return
+}
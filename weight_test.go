@@ -0,0 +1,41 @@
+package ward
+
+import "testing"
+
+func TestToPounds(t *testing.T) {
+	tests := []struct {
+		weight   uint
+		unit     WeightUnit
+		expected uint
+	}{
+		{100, Pounds, 100},
+		{100, Kilograms, 220},
+		{1, Kilograms, 2},
+		{0, Kilograms, 0},
+		{500, Kilograms, 1102},
+	}
+
+	for _, tt := range tests {
+		if got := toPounds(tt.weight, tt.unit); got != tt.expected {
+			t.Errorf("toPounds(%d, %d) = %d, expected %d", tt.weight, tt.unit, got, tt.expected)
+		}
+	}
+}
+
+func TestNormalizeWeights(t *testing.T) {
+	s := PickupRequestShipment{Weight: 100, WeightUnit: Kilograms}
+	s.normalizeWeight()
+	s.normalizeWeight()
+	if s.Weight != 220 || s.WeightUnit != Pounds {
+		t.Fatalf("expected 220 lbs, got %d in unit %d", s.Weight, s.WeightUnit)
+	}
+
+	r := RateQuoteRequestInner{Details: []RateQuoteDetailItem{
+		{Weight: 100, WeightUnit: Kilograms},
+		{Weight: 100},
+	}}
+	r.normalizeWeights()
+	if r.Details[0].Weight != 220 || r.Details[1].Weight != 100 {
+		t.Fatalf("expected 220 and 100 lbs, got %d and %d", r.Details[0].Weight, r.Details[1].Weight)
+	}
+}
@@ -0,0 +1,90 @@
+package ward
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+//ZipResolver looks up the city and state for a zip code
+//Ward wants a city and state along with each zip code but often you only have the zip.  Set a ZipResolver
+//backed by your own data source with SetZipResolver and any empty city or state on a request will be filled
+//in from the zip code when the request is sent.
+type ZipResolver interface {
+	Resolve(zip string) (city, state string, err error)
+}
+
+//noopZipResolver is the default ZipResolver, it never fills anything in
+type noopZipResolver struct{}
+
+//Resolve implements ZipResolver
+func (noopZipResolver) Resolve(zip string) (city, state string, err error) {
+	return
+}
+
+//SetZipResolver sets the ZipResolver used to fill in missing cities and states
+//Pass nil to stop filling in cities and states.
+func SetZipResolver(r ZipResolver) {
+	defaultClient.SetZipResolver(r)
+	return
+}
+
+//SetZipResolver sets the ZipResolver used to fill in missing cities and states, see SetZipResolver
+func (c *Client) SetZipResolver(r ZipResolver) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if r == nil {
+		r = noopZipResolver{}
+	}
+
+	c.zipResolver = r
+	return
+}
+
+//resolveCityState fills in the city and state from the zip code if either is empty
+//Values that are already set are never overwritten.
+func resolveCityState(resolver ZipResolver, zip string, city, state *string) error {
+	zip = strings.TrimSpace(zip)
+	if zip == "" || (*city != "" && *state != "") {
+		return nil
+	}
+
+	c, s, err := resolver.Resolve(zip)
+	if err != nil {
+		return errors.Wrap(err, "ward.resolveCityState - could not resolve "+zip)
+	}
+
+	if *city == "" {
+		*city = c
+	}
+	if *state == "" {
+		*state = s
+	}
+
+	return nil
+}
+
+//resolveZips fills in any missing shipper or consignee city and state
+func (p *PickupRequest) resolveZips(resolver ZipResolver) (err error) {
+	s := &p.ShipperInfo
+	err = resolveCityState(resolver, s.ShipperZipcode, &s.ShipperCity, &s.ShipperState)
+	if err != nil {
+		return
+	}
+
+	c := &p.Shipment
+	err = resolveCityState(resolver, c.ConsigneeZipcode, &c.ConsigneeCity, &c.ConsigneeState)
+	return
+}
+
+//resolveZips fills in any missing origin or destination city and state
+func (r *RateQuoteRequestInner) resolveZips(resolver ZipResolver) (err error) {
+	err = resolveCityState(resolver, r.OriginZipcode, &r.OriginCity, &r.OriginState)
+	if err != nil {
+		return
+	}
+
+	err = resolveCityState(resolver, r.DestinationZipcode, &r.DestinationCity, &r.DestinationState)
+	return
+}